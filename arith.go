@@ -0,0 +1,52 @@
+// Copyright (c) 2020 Meng Huang (mhboy@outlook.com)
+// This package is licensed under a MIT license that can be found in the LICENSE file.
+
+package atomic
+
+// addInt64 returns a+b clamped to [lo, hi], and whether it was in range.
+func addInt64(a, b, lo, hi int64) (sum int64, ok bool) {
+	sum = a + b
+	switch {
+	case b > 0 && sum < a, sum > hi:
+		return hi, false
+	case b < 0 && sum > a, sum < lo:
+		return lo, false
+	}
+	return sum, true
+}
+
+// subInt64 returns a-b clamped to [lo, hi], and whether it was in range.
+func subInt64(a, b, lo, hi int64) (diff int64, ok bool) {
+	diff = a - b
+	switch {
+	case b < 0 && diff < a, diff > hi:
+		return hi, false
+	case b > 0 && diff > a, diff < lo:
+		return lo, false
+	}
+	return diff, true
+}
+
+// addUint64 returns a+b clamped to [lo, hi], and whether it was in range.
+func addUint64(a, b, lo, hi uint64) (sum uint64, ok bool) {
+	sum = a + b
+	switch {
+	case sum < a, sum > hi:
+		return hi, false
+	case sum < lo:
+		return lo, false
+	}
+	return sum, true
+}
+
+// subUint64 returns a-b clamped to [lo, hi], and whether it was in range.
+func subUint64(a, b, lo, hi uint64) (diff uint64, ok bool) {
+	diff = a - b
+	switch {
+	case a < b, diff < lo:
+		return lo, false
+	case diff > hi:
+		return hi, false
+	}
+	return diff, true
+}
@@ -70,6 +70,84 @@ func CompareAndSwapPointer(addr *unsafe.Pointer, old, new unsafe.Pointer) (swapp
 	return atomic.CompareAndSwapPointer(addr, old, new)
 }
 
+// CompareAndExchangeInt32 executes the compare-and-swap operation for an int32 value
+// and returns the value of *addr it was compared with, which is old if the swap happened.
+func CompareAndExchangeInt32(addr *int32, old, new int32) (val int32) {
+	for {
+		if atomic.CompareAndSwapInt32(addr, old, new) {
+			return old
+		}
+		if val = atomic.LoadInt32(addr); val != old {
+			return
+		}
+	}
+}
+
+// CompareAndExchangeInt64 executes the compare-and-swap operation for an int64 value
+// and returns the value of *addr it was compared with, which is old if the swap happened.
+func CompareAndExchangeInt64(addr *int64, old, new int64) (val int64) {
+	for {
+		if atomic.CompareAndSwapInt64(addr, old, new) {
+			return old
+		}
+		if val = atomic.LoadInt64(addr); val != old {
+			return
+		}
+	}
+}
+
+// CompareAndExchangeUint32 executes the compare-and-swap operation for an uint32 value
+// and returns the value of *addr it was compared with, which is old if the swap happened.
+func CompareAndExchangeUint32(addr *uint32, old, new uint32) (val uint32) {
+	for {
+		if atomic.CompareAndSwapUint32(addr, old, new) {
+			return old
+		}
+		if val = atomic.LoadUint32(addr); val != old {
+			return
+		}
+	}
+}
+
+// CompareAndExchangeUint64 executes the compare-and-swap operation for an uint64 value
+// and returns the value of *addr it was compared with, which is old if the swap happened.
+func CompareAndExchangeUint64(addr *uint64, old, new uint64) (val uint64) {
+	for {
+		if atomic.CompareAndSwapUint64(addr, old, new) {
+			return old
+		}
+		if val = atomic.LoadUint64(addr); val != old {
+			return
+		}
+	}
+}
+
+// CompareAndExchangeUintptr executes the compare-and-swap operation for an uintptr value
+// and returns the value of *addr it was compared with, which is old if the swap happened.
+func CompareAndExchangeUintptr(addr *uintptr, old, new uintptr) (val uintptr) {
+	for {
+		if atomic.CompareAndSwapUintptr(addr, old, new) {
+			return old
+		}
+		if val = atomic.LoadUintptr(addr); val != old {
+			return
+		}
+	}
+}
+
+// CompareAndExchangePointer executes the compare-and-swap operation for a unsafe.Pointer value
+// and returns the value of *addr it was compared with, which is old if the swap happened.
+func CompareAndExchangePointer(addr *unsafe.Pointer, old, new unsafe.Pointer) (val unsafe.Pointer) {
+	for {
+		if atomic.CompareAndSwapPointer(addr, old, new) {
+			return old
+		}
+		if val = atomic.LoadPointer(addr); val != old {
+			return
+		}
+	}
+}
+
 // AddInt32 atomically adds delta to *addr and returns the new value.
 func AddInt32(addr *int32, delta int32) (new int32) {
 	return atomic.AddInt32(addr, delta)
@@ -99,6 +177,166 @@ func AddUintptr(addr *uintptr, delta uintptr) (new uintptr) {
 	return atomic.AddUintptr(addr, delta)
 }
 
+// FetchAddInt32 atomically adds delta to *addr and returns the previous value.
+func FetchAddInt32(addr *int32, delta int32) (old int32) {
+	return atomic.AddInt32(addr, delta) - delta
+}
+
+// FetchAddInt64 atomically adds delta to *addr and returns the previous value.
+func FetchAddInt64(addr *int64, delta int64) (old int64) {
+	return atomic.AddInt64(addr, delta) - delta
+}
+
+// FetchAddUint32 atomically adds delta to *addr and returns the previous value.
+func FetchAddUint32(addr *uint32, delta uint32) (old uint32) {
+	return atomic.AddUint32(addr, delta) - delta
+}
+
+// FetchAddUint64 atomically adds delta to *addr and returns the previous value.
+func FetchAddUint64(addr *uint64, delta uint64) (old uint64) {
+	return atomic.AddUint64(addr, delta) - delta
+}
+
+// FetchAddUintptr atomically adds delta to *addr and returns the previous value.
+func FetchAddUintptr(addr *uintptr, delta uintptr) (old uintptr) {
+	return atomic.AddUintptr(addr, delta) - delta
+}
+
+// FetchSubInt32 atomically subtracts delta from *addr and returns the previous value.
+func FetchSubInt32(addr *int32, delta int32) (old int32) {
+	return atomic.AddInt32(addr, -delta) + delta
+}
+
+// FetchSubInt64 atomically subtracts delta from *addr and returns the previous value.
+func FetchSubInt64(addr *int64, delta int64) (old int64) {
+	return atomic.AddInt64(addr, -delta) + delta
+}
+
+// FetchSubUint32 atomically subtracts delta from *addr and returns the previous value.
+func FetchSubUint32(addr *uint32, delta uint32) (old uint32) {
+	return atomic.AddUint32(addr, -delta) + delta
+}
+
+// FetchSubUint64 atomically subtracts delta from *addr and returns the previous value.
+func FetchSubUint64(addr *uint64, delta uint64) (old uint64) {
+	return atomic.AddUint64(addr, -delta) + delta
+}
+
+// FetchSubUintptr atomically subtracts delta from *addr and returns the previous value.
+func FetchSubUintptr(addr *uintptr, delta uintptr) (old uintptr) {
+	return atomic.AddUintptr(addr, -delta) + delta
+}
+
+// FetchAndInt32 atomically replaces *addr with the bitwise AND of *addr and mask
+// and returns the previous value.
+func FetchAndInt32(addr *int32, mask int32) (old int32) {
+	for {
+		old = atomic.LoadInt32(addr)
+		if old&mask == old || atomic.CompareAndSwapInt32(addr, old, old&mask) {
+			return
+		}
+	}
+}
+
+// FetchAndInt64 atomically replaces *addr with the bitwise AND of *addr and mask
+// and returns the previous value.
+func FetchAndInt64(addr *int64, mask int64) (old int64) {
+	for {
+		old = atomic.LoadInt64(addr)
+		if old&mask == old || atomic.CompareAndSwapInt64(addr, old, old&mask) {
+			return
+		}
+	}
+}
+
+// FetchAndUint32 atomically replaces *addr with the bitwise AND of *addr and mask
+// and returns the previous value.
+func FetchAndUint32(addr *uint32, mask uint32) (old uint32) {
+	for {
+		old = atomic.LoadUint32(addr)
+		if old&mask == old || atomic.CompareAndSwapUint32(addr, old, old&mask) {
+			return
+		}
+	}
+}
+
+// FetchAndUint64 atomically replaces *addr with the bitwise AND of *addr and mask
+// and returns the previous value.
+func FetchAndUint64(addr *uint64, mask uint64) (old uint64) {
+	for {
+		old = atomic.LoadUint64(addr)
+		if old&mask == old || atomic.CompareAndSwapUint64(addr, old, old&mask) {
+			return
+		}
+	}
+}
+
+// FetchAndUintptr atomically replaces *addr with the bitwise AND of *addr and mask
+// and returns the previous value.
+func FetchAndUintptr(addr *uintptr, mask uintptr) (old uintptr) {
+	for {
+		old = atomic.LoadUintptr(addr)
+		if old&mask == old || atomic.CompareAndSwapUintptr(addr, old, old&mask) {
+			return
+		}
+	}
+}
+
+// FetchOrInt32 atomically replaces *addr with the bitwise OR of *addr and mask
+// and returns the previous value.
+func FetchOrInt32(addr *int32, mask int32) (old int32) {
+	for {
+		old = atomic.LoadInt32(addr)
+		if old|mask == old || atomic.CompareAndSwapInt32(addr, old, old|mask) {
+			return
+		}
+	}
+}
+
+// FetchOrInt64 atomically replaces *addr with the bitwise OR of *addr and mask
+// and returns the previous value.
+func FetchOrInt64(addr *int64, mask int64) (old int64) {
+	for {
+		old = atomic.LoadInt64(addr)
+		if old|mask == old || atomic.CompareAndSwapInt64(addr, old, old|mask) {
+			return
+		}
+	}
+}
+
+// FetchOrUint32 atomically replaces *addr with the bitwise OR of *addr and mask
+// and returns the previous value.
+func FetchOrUint32(addr *uint32, mask uint32) (old uint32) {
+	for {
+		old = atomic.LoadUint32(addr)
+		if old|mask == old || atomic.CompareAndSwapUint32(addr, old, old|mask) {
+			return
+		}
+	}
+}
+
+// FetchOrUint64 atomically replaces *addr with the bitwise OR of *addr and mask
+// and returns the previous value.
+func FetchOrUint64(addr *uint64, mask uint64) (old uint64) {
+	for {
+		old = atomic.LoadUint64(addr)
+		if old|mask == old || atomic.CompareAndSwapUint64(addr, old, old|mask) {
+			return
+		}
+	}
+}
+
+// FetchOrUintptr atomically replaces *addr with the bitwise OR of *addr and mask
+// and returns the previous value.
+func FetchOrUintptr(addr *uintptr, mask uintptr) (old uintptr) {
+	for {
+		old = atomic.LoadUintptr(addr)
+		if old|mask == old || atomic.CompareAndSwapUintptr(addr, old, old|mask) {
+			return
+		}
+	}
+}
+
 // LoadInt32 atomically loads *addr.
 func LoadInt32(addr *int32) (val int32) {
 	return atomic.LoadInt32(addr)
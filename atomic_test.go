@@ -137,3 +137,124 @@ func TestAtomicPointer(t *testing.T) {
 		t.Log(LoadPointer(&vp))
 	}
 }
+
+func TestAtomicFetchInt32(t *testing.T) {
+	var v int32 = 6
+	if FetchAddInt32(&v, 2) != 6 || v != 8 {
+		t.Error(v)
+	}
+	if FetchSubInt32(&v, 3) != 8 || v != 5 {
+		t.Error(v)
+	}
+	if FetchAndInt32(&v, 6) != 5 || v != 4 {
+		t.Error(v)
+	}
+	if FetchOrInt32(&v, 3) != 4 || v != 7 {
+		t.Error(v)
+	}
+	if CompareAndExchangeInt32(&v, 7, 9) != 7 || v != 9 {
+		t.Error(v)
+	}
+	if CompareAndExchangeInt32(&v, 7, 10) != 9 || v != 9 {
+		t.Error(v)
+	}
+}
+
+func TestAtomicFetchInt64(t *testing.T) {
+	var v int64 = 6
+	if FetchAddInt64(&v, 2) != 6 || v != 8 {
+		t.Error(v)
+	}
+	if FetchSubInt64(&v, 3) != 8 || v != 5 {
+		t.Error(v)
+	}
+	if FetchAndInt64(&v, 6) != 5 || v != 4 {
+		t.Error(v)
+	}
+	if FetchOrInt64(&v, 3) != 4 || v != 7 {
+		t.Error(v)
+	}
+	if CompareAndExchangeInt64(&v, 7, 9) != 7 || v != 9 {
+		t.Error(v)
+	}
+	if CompareAndExchangeInt64(&v, 7, 10) != 9 || v != 9 {
+		t.Error(v)
+	}
+}
+
+func TestAtomicFetchUint32(t *testing.T) {
+	var v uint32 = 6
+	if FetchAddUint32(&v, 2) != 6 || v != 8 {
+		t.Error(v)
+	}
+	if FetchSubUint32(&v, 3) != 8 || v != 5 {
+		t.Error(v)
+	}
+	if FetchAndUint32(&v, 6) != 5 || v != 4 {
+		t.Error(v)
+	}
+	if FetchOrUint32(&v, 3) != 4 || v != 7 {
+		t.Error(v)
+	}
+	if CompareAndExchangeUint32(&v, 7, 9) != 7 || v != 9 {
+		t.Error(v)
+	}
+	if CompareAndExchangeUint32(&v, 7, 10) != 9 || v != 9 {
+		t.Error(v)
+	}
+}
+
+func TestAtomicFetchUint64(t *testing.T) {
+	var v uint64 = 6
+	if FetchAddUint64(&v, 2) != 6 || v != 8 {
+		t.Error(v)
+	}
+	if FetchSubUint64(&v, 3) != 8 || v != 5 {
+		t.Error(v)
+	}
+	if FetchAndUint64(&v, 6) != 5 || v != 4 {
+		t.Error(v)
+	}
+	if FetchOrUint64(&v, 3) != 4 || v != 7 {
+		t.Error(v)
+	}
+	if CompareAndExchangeUint64(&v, 7, 9) != 7 || v != 9 {
+		t.Error(v)
+	}
+	if CompareAndExchangeUint64(&v, 7, 10) != 9 || v != 9 {
+		t.Error(v)
+	}
+}
+
+func TestAtomicFetchUintptr(t *testing.T) {
+	var v uintptr = 6
+	if FetchAddUintptr(&v, 2) != 6 || v != 8 {
+		t.Error(v)
+	}
+	if FetchSubUintptr(&v, 3) != 8 || v != 5 {
+		t.Error(v)
+	}
+	if FetchAndUintptr(&v, 6) != 5 || v != 4 {
+		t.Error(v)
+	}
+	if FetchOrUintptr(&v, 3) != 4 || v != 7 {
+		t.Error(v)
+	}
+	if CompareAndExchangeUintptr(&v, 7, 9) != 7 || v != 9 {
+		t.Error(v)
+	}
+	if CompareAndExchangeUintptr(&v, 7, 10) != 9 || v != 9 {
+		t.Error(v)
+	}
+}
+
+func TestAtomicCompareAndExchangePointer(t *testing.T) {
+	var a, b = 1, 2
+	var v unsafe.Pointer
+	if CompareAndExchangePointer(&v, nil, unsafe.Pointer(&a)) != nil || v != unsafe.Pointer(&a) {
+		t.Error(v)
+	}
+	if CompareAndExchangePointer(&v, nil, unsafe.Pointer(&b)) != unsafe.Pointer(&a) || v != unsafe.Pointer(&a) {
+		t.Error(v)
+	}
+}
@@ -32,7 +32,9 @@ func (addr *Bytes) Swap(new []byte) (old []byte) {
 	for {
 		load := addr.v.Load()
 		if addr.v.compareAndSwap(load, new) {
-			return load.([]byte)
+			// load is nil before the first store.
+			old, _ = load.([]byte)
+			return
 		}
 	}
 }
@@ -40,7 +42,8 @@ func (addr *Bytes) Swap(new []byte) (old []byte) {
 // CompareAndSwap executes the compare-and-swap operation for an []byte value.
 func (addr *Bytes) CompareAndSwap(old, new []byte) (swapped bool) {
 	load := addr.v.Load()
-	if !bytesEqual(old, load.([]byte)) {
+	val, _ := load.([]byte)
+	if !bytesEqual(old, val) {
 		return false
 	}
 	return addr.v.compareAndSwap(load, new)
@@ -49,9 +52,24 @@ func (addr *Bytes) CompareAndSwap(old, new []byte) (swapped bool) {
 // Add atomically adds delta to *addr and returns the new value.
 func (addr *Bytes) Add(delta []byte) (new []byte) {
 	for {
-		old := addr.v.Load()
-		new = append(old.([]byte), delta...)
-		if addr.v.compareAndSwap(old, new) {
+		load := addr.v.Load()
+		old, _ := load.([]byte)
+		new = append(old, delta...)
+		if addr.v.compareAndSwap(load, new) {
+			return
+		}
+	}
+}
+
+// FetchAdd atomically appends delta to *addr and returns the previous value.
+func (addr *Bytes) FetchAdd(delta []byte) (old []byte) {
+	for {
+		load := addr.v.Load()
+		// load is nil before the first store.
+		old, _ = load.([]byte)
+		// Limit the capacity so that append never writes into the
+		// backing array shared with old.
+		if addr.v.compareAndSwap(load, append(old[:len(old):len(old)], delta...)) {
 			return
 		}
 	}
@@ -70,3 +88,14 @@ func (addr *Bytes) Load() (val []byte) {
 func (addr *Bytes) Store(val []byte) {
 	addr.v.Store(val)
 }
+
+// Watch returns a new Watcher that is notified after each successful write to addr.
+// The Value of each Event holds an []byte.
+func (addr *Bytes) Watch() *Watcher {
+	return addr.v.Watch()
+}
+
+// Generation returns the number of successful writes to addr.
+func (addr *Bytes) Generation() uint64 {
+	return addr.v.Generation()
+}
@@ -91,6 +91,32 @@ func TestSwapBytes(t *testing.T) {
 	wg.Wait()
 }
 
+func TestFetchAddBytes(t *testing.T) {
+	addr := NewBytes(make([]byte, 1, 8))
+	old := addr.FetchAdd([]byte{1})
+	if !bytesEqual(old, []byte{0}) || !bytesEqual(addr.Load(), []byte{0, 1}) {
+		t.Error(addr.Load())
+	}
+	if &old[:2][1] == &addr.Load()[1] {
+		t.Error("should not share the spare capacity of the previous value")
+	}
+}
+
+func TestFetchAddZeroBytes(t *testing.T) {
+	var addr Bytes
+	if old := addr.FetchAdd([]byte{1}); old != nil || !bytesEqual(addr.Load(), []byte{1}) {
+		t.Error(old, addr.Load())
+	}
+	var zero Bytes
+	if new := zero.Add([]byte{1}); !bytesEqual(new, []byte{1}) || !bytesEqual(zero.Load(), []byte{1}) {
+		t.Error(zero.Load())
+	}
+	var cas Bytes
+	if !cas.CompareAndSwap(nil, []byte{1}) || !bytesEqual(cas.Load(), []byte{1}) {
+		t.Error(cas.Load())
+	}
+}
+
 func BenchmarkSwapBytes(b *testing.B) {
 	addr := NewBytes(nil)
 	for i := 0; i < b.N; i++ {
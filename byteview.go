@@ -0,0 +1,72 @@
+// Copyright (c) 2020 Meng Huang (mhboy@outlook.com)
+// This package is licensed under a MIT license that can be found in the LICENSE file.
+
+package atomic
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"unsafe"
+)
+
+// ErrInvalidOffset is returned when a view does not fit in a buffer or is not
+// aligned for atomic access.
+var ErrInvalidOffset = errors.New("github.com/hslam/atomic: invalid offset")
+
+// nativeLittleEndian reports whether the machine is little-endian.
+var nativeLittleEndian = func() bool {
+	x := uint16(1)
+	return *(*byte)(unsafe.Pointer(&x)) == 1
+}()
+
+// Uint32At returns a view of the uint32 at offset in buf.
+//
+// The bytes must stay valid and must not move while the view is used, which
+// holds for slices from make and for memory mapped regions. If order is nil,
+// the uint32 is in the native byte order. Otherwise it is in order, so that
+// peers that read buf with order see the same value.
+func Uint32At(buf []byte, offset int, order binary.ByteOrder) (*Uint32View, error) {
+	p, swap, err := viewAt(buf, offset, 4, order)
+	if err != nil {
+		return nil, err
+	}
+	return &Uint32View{addr: (*uint32)(p), swap: swap}, nil
+}
+
+// Uint64At returns a view of the uint64 at offset in buf.
+// See Uint32At for the requirements on buf and order.
+func Uint64At(buf []byte, offset int, order binary.ByteOrder) (*Uint64View, error) {
+	p, swap, err := viewAt(buf, offset, 8, order)
+	if err != nil {
+		return nil, err
+	}
+	return &Uint64View{addr: (*uint64)(p), swap: swap}, nil
+}
+
+// Int64At returns a view of the int64 at offset in buf.
+// See Uint32At for the requirements on buf and order.
+func Int64At(buf []byte, offset int, order binary.ByteOrder) (*Int64View, error) {
+	p, swap, err := viewAt(buf, offset, 8, order)
+	if err != nil {
+		return nil, err
+	}
+	return &Int64View{addr: (*int64)(p), swap: swap}, nil
+}
+
+// viewAt returns the address of the size bytes at offset in buf after
+// checking bounds and alignment, and whether order is not the native one.
+func viewAt(buf []byte, offset, size int, order binary.ByteOrder) (unsafe.Pointer, bool, error) {
+	if offset < 0 || offset > len(buf)-size {
+		return nil, false, fmt.Errorf("%w: %d bytes at offset %d of a %d-byte buffer", ErrInvalidOffset, size, offset, len(buf))
+	}
+	p := unsafe.Pointer(&buf[offset])
+	if uintptr(p)%uintptr(size) != 0 {
+		return nil, false, fmt.Errorf("%w: offset %d is not %d-byte aligned", ErrInvalidOffset, offset, size)
+	}
+	if order == nil {
+		return p, false, nil
+	}
+	littleEndian := order.Uint16([]byte{1, 0}) == 1
+	return p, littleEndian != nativeLittleEndian, nil
+}
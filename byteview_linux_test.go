@@ -0,0 +1,22 @@
+// Copyright (c) 2020 Meng Huang (mhboy@outlook.com)
+// This package is licensed under a MIT license that can be found in the LICENSE file.
+
+//go:build linux
+// +build linux
+
+package atomic
+
+import (
+	"syscall"
+	"testing"
+)
+
+func TestByteViewsMmap(t *testing.T) {
+	buf, err := syscall.Mmap(-1, 0, syscall.Getpagesize(), syscall.PROT_READ|syscall.PROT_WRITE, syscall.MAP_ANON|syscall.MAP_SHARED)
+	if err != nil {
+		t.Skip(err)
+	}
+	defer syscall.Munmap(buf)
+	testByteViews(t, buf)
+	testByteViews(t, buf[len(buf)-32:])
+}
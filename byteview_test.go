@@ -0,0 +1,99 @@
+// Copyright (c) 2020 Meng Huang (mhboy@outlook.com)
+// This package is licensed under a MIT license that can be found in the LICENSE file.
+
+package atomic
+
+import (
+	"encoding/binary"
+	"errors"
+	"sync"
+	"testing"
+)
+
+// testByteViews checks views at the start of buf, which must be 8-byte
+// aligned and at least 32 bytes long.
+func testByteViews(t *testing.T, buf []byte) {
+	for _, order := range []binary.ByteOrder{nil, binary.LittleEndian, binary.BigEndian} {
+		u32, err := Uint32At(buf, 4, order)
+		if err != nil {
+			t.Fatal(err)
+		}
+		u64, err := Uint64At(buf, 8, order)
+		if err != nil {
+			t.Fatal(err)
+		}
+		i64, err := Int64At(buf, 16, order)
+		if err != nil {
+			t.Fatal(err)
+		}
+		u32.Store(0x01020304)
+		u64.Store(0x0102030405060708)
+		i64.Store(-2)
+		if order != nil {
+			if order.Uint32(buf[4:]) != 0x01020304 || order.Uint64(buf[8:]) != 0x0102030405060708 || int64(order.Uint64(buf[16:])) != -2 {
+				t.Errorf("%v: %x", order, buf[:24])
+			}
+			order.PutUint32(buf[4:], 7)
+			if u32.Load() != 7 {
+				t.Error(order, u32.Load())
+			}
+		} else {
+			u32.Store(7)
+		}
+		if u32.Add(1) != 8 || u64.Add(1) != 0x0102030405060709 || i64.Add(1) != -1 {
+			t.Error(order, u32.Load(), u64.Load(), i64.Load())
+		}
+		if !u32.CompareAndSwap(8, 9) || u32.CompareAndSwap(8, 9) || u32.Swap(10) != 9 {
+			t.Error(order, u32.Load())
+		}
+		if !u64.CompareAndSwap(0x0102030405060709, 1) || u64.Swap(2) != 1 {
+			t.Error(order, u64.Load())
+		}
+		if !i64.CompareAndSwap(-1, 1) || i64.Swap(2) != 1 {
+			t.Error(order, i64.Load())
+		}
+		var wg sync.WaitGroup
+		for i := 0; i < 8192; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				u32.Add(1)
+				u64.Add(1)
+				i64.Add(-1)
+			}()
+		}
+		wg.Wait()
+		if u32.Load() != 10+8192 || u64.Load() != 2+8192 || i64.Load() != 2-8192 {
+			t.Error(order, u32.Load(), u64.Load(), i64.Load())
+		}
+	}
+}
+
+func TestByteViews(t *testing.T) {
+	testByteViews(t, make([]byte, 32))
+}
+
+func TestByteViewsInvalid(t *testing.T) {
+	buf := make([]byte, 32)
+	for _, err := range []error{
+		func() error { _, err := Uint32At(buf, 2, nil); return err }(),
+		func() error { _, err := Uint32At(buf, 32, nil); return err }(),
+		func() error { _, err := Uint32At(buf, -4, nil); return err }(),
+		func() error { _, err := Uint32At(nil, 0, nil); return err }(),
+		func() error { _, err := Uint64At(buf, 4, nil); return err }(),
+		func() error { _, err := Uint64At(buf, 28, nil); return err }(),
+		func() error { _, err := Int64At(buf, 12, binary.BigEndian); return err }(),
+		func() error { _, err := Int64At(buf, 32, nil); return err }(),
+	} {
+		if !errors.Is(err, ErrInvalidOffset) {
+			t.Error(err)
+		}
+	}
+}
+
+func BenchmarkAddUint32At(b *testing.B) {
+	v, _ := Uint32At(make([]byte, 8), 0, binary.BigEndian)
+	for i := 0; i < b.N; i++ {
+		v.Add(1)
+	}
+}
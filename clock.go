@@ -0,0 +1,66 @@
+// Copyright (c) 2020 Meng Huang (mhboy@outlook.com)
+// This package is licensed under a MIT license that can be found in the LICENSE file.
+
+package atomic
+
+import (
+	"context"
+	"time"
+)
+
+// Clock provides the current time and timers to the time-driven types of
+// this package. It can be replaced in tests to make them deterministic.
+type Clock interface {
+	Now() time.Time
+	// NewTimer returns a channel that receives the time after d, and a
+	// function that stops the timer like time.Timer.Stop.
+	NewTimer(d time.Duration) (c <-chan time.Time, stop func() bool)
+}
+
+// systemClock is the Clock used when none is given.
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) NewTimer(d time.Duration) (c <-chan time.Time, stop func() bool) {
+	timer := time.NewTimer(d)
+	return timer.C, timer.Stop
+}
+
+func clockOrSystem(clock Clock) Clock {
+	if clock == nil {
+		return systemClock{}
+	}
+	return clock
+}
+
+// sleep waits for delay on clock or until ctx is done. If ctx is done first,
+// or its deadline is earlier than delay, cancel is called and the context
+// error is returned. Context deadlines are in real time, so they are
+// compared with the system clock whatever clock is.
+func sleep(ctx context.Context, clock Clock, delay time.Duration, cancel func()) error {
+	select {
+	case <-ctx.Done():
+		cancel()
+		return ctx.Err()
+	default:
+	}
+	if delay <= 0 {
+		return nil
+	}
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+		cancel()
+		return context.DeadlineExceeded
+	}
+	c, stop := clock.NewTimer(delay)
+	defer stop()
+	select {
+	case <-c:
+		return nil
+	case <-ctx.Done():
+		cancel()
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,152 @@
+// Copyright (c) 2020 Meng Huang (mhboy@outlook.com)
+// This package is licensed under a MIT license that can be found in the LICENSE file.
+
+package atomic
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock is a Clock that only moves when advanced.
+type fakeClock struct {
+	now    Int64
+	mu     sync.Mutex
+	timers []*fakeTimer
+}
+
+type fakeTimer struct {
+	at int64
+	c  chan time.Time
+}
+
+func newFakeClock() *fakeClock {
+	c := &fakeClock{}
+	c.now.Store(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC).UnixNano())
+	return c
+}
+
+func (c *fakeClock) Now() time.Time {
+	return time.Unix(0, c.now.Load())
+}
+
+func (c *fakeClock) NewTimer(d time.Duration) (<-chan time.Time, func() bool) {
+	t := &fakeTimer{at: c.now.Load() + int64(d), c: make(chan time.Time, 1)}
+	c.mu.Lock()
+	c.timers = append(c.timers, t)
+	c.mu.Unlock()
+	c.Advance(0)
+	stop := func() bool {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		for i, timer := range c.timers {
+			if timer == t {
+				c.timers = append(c.timers[:i], c.timers[i+1:]...)
+				return true
+			}
+		}
+		return false
+	}
+	return t.c, stop
+}
+
+// Advance moves the clock forward by d and fires the timers that are due.
+func (c *fakeClock) Advance(d time.Duration) {
+	now := c.now.Add(int64(d))
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	timers := c.timers[:0]
+	for _, t := range c.timers {
+		if t.at <= now {
+			t.c <- time.Unix(0, now)
+		} else {
+			timers = append(timers, t)
+		}
+	}
+	c.timers = timers
+}
+
+// WaitTimers blocks until n timers are pending.
+func (c *fakeClock) WaitTimers(n int) {
+	for {
+		c.mu.Lock()
+		pending := len(c.timers)
+		c.mu.Unlock()
+		if pending >= n {
+			return
+		}
+		runtime.Gosched()
+	}
+}
+
+func TestClock(t *testing.T) {
+	if clockOrSystem(nil) != (systemClock{}) {
+		t.Error("should use the system clock")
+	}
+	before := time.Now()
+	if now := clockOrSystem(nil).Now(); now.Before(before) {
+		t.Error(now)
+	}
+	clock := newFakeClock()
+	if clockOrSystem(clock) != clock {
+		t.Error("should use the given clock")
+	}
+}
+
+func TestSleep(t *testing.T) {
+	clock := newFakeClock()
+	var canceled int
+	cancel := func() { canceled++ }
+	if err := sleep(context.Background(), clock, 0, cancel); err != nil || canceled != 0 {
+		t.Error(err, canceled)
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- sleep(context.Background(), clock, time.Minute, cancel)
+	}()
+	clock.WaitTimers(1)
+	clock.Advance(time.Second)
+	select {
+	case err := <-done:
+		t.Error("should wait", err)
+	default:
+	}
+	clock.Advance(time.Minute)
+	if err := <-done; err != nil || canceled != 0 {
+		t.Error(err, canceled)
+	}
+	ctx, stop := context.WithCancel(context.Background())
+	stop()
+	if err := sleep(ctx, clock, 0, cancel); err != context.Canceled || canceled != 1 {
+		t.Error(err, canceled)
+	}
+	// The deadline is earlier than the delay on the fake clock.
+	ctx, stop = context.WithTimeout(context.Background(), time.Minute)
+	defer stop()
+	if err := sleep(ctx, clock, time.Hour, cancel); err != context.DeadlineExceeded || canceled != 2 {
+		t.Error(err, canceled)
+	}
+	ctx, stop = context.WithCancel(context.Background())
+	go func() {
+		done <- sleep(ctx, clock, time.Minute, cancel)
+	}()
+	clock.WaitTimers(1)
+	stop()
+	if err := <-done; err != context.Canceled || canceled != 3 {
+		t.Error(err, canceled)
+	}
+	if len(clock.timers) != 0 {
+		t.Error("should stop the timer", len(clock.timers))
+	}
+	if err := sleep(context.Background(), systemClock{}, time.Millisecond, cancel); err != nil || canceled != 3 {
+		t.Error(err, canceled)
+	}
+	ctx, stop = context.WithTimeout(context.Background(), time.Millisecond)
+	defer stop()
+	if err := sleep(ctx, systemClock{}, time.Millisecond*500, cancel); err != context.DeadlineExceeded || canceled != 4 {
+		t.Error(err, canceled)
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright (c) 2020 Meng Huang (mhboy@outlook.com)
+// This package is licensed under a MIT license that can be found in the LICENSE file.
+
+package atomic
+
+import (
+	"math"
+	"runtime"
+	"sync/atomic"
+)
+
+// Complex128 represents an complex128.
+//
+// A complex128 does not fit in a single machine word, so the real and
+// imaginary parts are guarded by a sequence lock. Writers serialize on
+// the sequence number while readers retry until they observe a
+// consistent pair, so Load never blocks a writer.
+type Complex128 struct {
+	seq uint64
+	re  uint64
+	im  uint64
+}
+
+// NewComplex128 returns a new Complex128.
+func NewComplex128(val complex128) *Complex128 {
+	addr := &Complex128{}
+	addr.Store(val)
+	return addr
+}
+
+// Swap atomically stores new into *addr and returns the previous *addr value.
+func (addr *Complex128) Swap(new complex128) (old complex128) {
+	seq := addr.lock()
+	old = addr.load()
+	addr.store(new)
+	addr.unlock(seq)
+	return
+}
+
+// CompareAndSwap executes the compare-and-swap operation for an complex128 value.
+func (addr *Complex128) CompareAndSwap(old, new complex128) (swapped bool) {
+	seq := addr.lock()
+	if addr.equal(old) {
+		addr.store(new)
+		swapped = true
+	}
+	addr.unlock(seq)
+	return
+}
+
+// Add atomically adds delta to *addr and returns the new value.
+func (addr *Complex128) Add(delta complex128) (new complex128) {
+	seq := addr.lock()
+	new = addr.load() + delta
+	addr.store(new)
+	addr.unlock(seq)
+	return
+}
+
+// Update atomically replaces *addr with f(*addr) and returns the new value.
+// f is called exactly once while addr is locked, so it must not access addr
+// at all: even a Load of addr inside f spins forever.
+func (addr *Complex128) Update(f func(old complex128) (new complex128)) (new complex128) {
+	seq := addr.lock()
+	new = f(addr.load())
+	addr.store(new)
+	addr.unlock(seq)
+	return
+}
+
+// FetchAdd atomically adds delta to *addr and returns the previous value.
+func (addr *Complex128) FetchAdd(delta complex128) (old complex128) {
+	seq := addr.lock()
+	old = addr.load()
+	addr.store(old + delta)
+	addr.unlock(seq)
+	return
+}
+
+// FetchSub atomically subtracts delta from *addr and returns the previous value.
+func (addr *Complex128) FetchSub(delta complex128) (old complex128) {
+	seq := addr.lock()
+	old = addr.load()
+	addr.store(old - delta)
+	addr.unlock(seq)
+	return
+}
+
+// CompareAndExchange executes the compare-and-swap operation for an complex128 value
+// and returns the value of *addr it was compared with, which is old if the swap happened.
+// Like CompareAndSwap, it compares the bit patterns of the values.
+func (addr *Complex128) CompareAndExchange(old, new complex128) (val complex128) {
+	seq := addr.lock()
+	if addr.equal(old) {
+		addr.store(new)
+		val = old
+	} else {
+		val = addr.load()
+	}
+	addr.unlock(seq)
+	return
+}
+
+// Load atomically loads *addr.
+func (addr *Complex128) Load() (val complex128) {
+	for {
+		seq := atomic.LoadUint64(&addr.seq)
+		if seq&1 == 0 {
+			val = addr.load()
+			if atomic.LoadUint64(&addr.seq) == seq {
+				return
+			}
+		}
+		runtime.Gosched()
+	}
+}
+
+// Store atomically stores val into *addr.
+func (addr *Complex128) Store(val complex128) {
+	seq := addr.lock()
+	addr.store(val)
+	addr.unlock(seq)
+}
+
+// lock acquires the sequence lock and returns the odd sequence number
+// that marks the write in progress.
+func (addr *Complex128) lock() uint64 {
+	for {
+		seq := atomic.LoadUint64(&addr.seq)
+		if seq&1 == 0 && atomic.CompareAndSwapUint64(&addr.seq, seq, seq+1) {
+			return seq + 1
+		}
+		runtime.Gosched()
+	}
+}
+
+// unlock releases the sequence lock acquired by lock.
+func (addr *Complex128) unlock(seq uint64) {
+	atomic.StoreUint64(&addr.seq, seq+1)
+}
+
+func (addr *Complex128) load() complex128 {
+	re := math.Float64frombits(atomic.LoadUint64(&addr.re))
+	im := math.Float64frombits(atomic.LoadUint64(&addr.im))
+	return complex(re, im)
+}
+
+func (addr *Complex128) store(val complex128) {
+	atomic.StoreUint64(&addr.re, math.Float64bits(real(val)))
+	atomic.StoreUint64(&addr.im, math.Float64bits(imag(val)))
+}
+
+func (addr *Complex128) equal(val complex128) bool {
+	return atomic.LoadUint64(&addr.re) == math.Float64bits(real(val)) &&
+		atomic.LoadUint64(&addr.im) == math.Float64bits(imag(val))
+}
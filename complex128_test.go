@@ -0,0 +1,177 @@
+// Copyright (c) 2020 Meng Huang (mhboy@outlook.com)
+// This package is licensed under a MIT license that can be found in the LICENSE file.
+
+package atomic
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestComplex128(t *testing.T) {
+	addr := NewComplex128(complex(1, -1))
+	if addr.Load() != complex(1, -1) {
+		t.Error(addr.Load())
+	}
+	addr.Store(complex(0.5, 0.25))
+	if addr.Load() != complex(0.5, 0.25) {
+		t.Error(addr.Load())
+	}
+	var delta = complex128(complex(0.5, 0.25))
+	if addr.Add(delta) != complex(1, 0.5) {
+		t.Error(addr.Load())
+	}
+	if addr.Load() != complex(1, 0.5) {
+		t.Error(addr.Load())
+	}
+	var new = complex128(complex(2, 3))
+	if addr.Swap(new) != complex(1, 0.5) {
+		t.Error(addr.Load())
+	}
+	var old = new
+	new = complex(4, 5)
+	if !addr.CompareAndSwap(old, new) {
+		t.Error(addr.Load())
+	}
+	if addr.CompareAndSwap(old, new) {
+		t.Error(addr.Load())
+	}
+	if addr.Update(func(old complex128) complex128 { return old * 2 }) != complex(8, 10) {
+		t.Error(addr.Load())
+	}
+}
+
+func TestAddComplex128(t *testing.T) {
+	addr := NewComplex128(0)
+	var wg sync.WaitGroup
+	for i := 0; i < 8192; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			addr.Add(complex(1, -1))
+		}()
+	}
+	wg.Wait()
+	if addr.Load() != complex(8192, -8192) {
+		t.Error(addr.Load())
+	}
+}
+
+func TestCompareAndSwapComplex128(t *testing.T) {
+	addr := NewComplex128(complex(1, 1))
+	var wg sync.WaitGroup
+	for i := 0; i < 8192; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			addr.CompareAndSwap(complex(1, 1), complex(2, 2))
+		}()
+	}
+	wg.Wait()
+}
+
+func TestSwapComplex128(t *testing.T) {
+	addr := NewComplex128(complex(1, 1))
+	var wg sync.WaitGroup
+	for i := 0; i < 8192; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			addr.Swap(complex(1, 1))
+		}()
+	}
+	wg.Wait()
+}
+
+func TestFetchComplex128(t *testing.T) {
+	addr := NewComplex128(0.5)
+	if addr.FetchAdd(0.25) != 0.5 || addr.Load() != 0.75 {
+		t.Error(addr.Load())
+	}
+	if addr.FetchSub(0.5) != 0.75 || addr.Load() != 0.25 {
+		t.Error(addr.Load())
+	}
+	if addr.CompareAndExchange(0.25, 1) != 0.25 || addr.Load() != 1 {
+		t.Error(addr.Load())
+	}
+	if addr.CompareAndExchange(0.25, 2) != 1 || addr.Load() != 1 {
+		t.Error(addr.Load())
+	}
+}
+
+func TestFetchAddComplex128(t *testing.T) {
+	addr := NewComplex128(0)
+	var seen [64]Bool
+	var wg sync.WaitGroup
+	for i := 0; i < 64; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if seen[int(real(complex128(addr.FetchAdd(1))))].Swap(true) {
+				t.Error("duplicate")
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func BenchmarkSwapComplex128(b *testing.B) {
+	addr := NewComplex128(complex(1, 1))
+	for i := 0; i < b.N; i++ {
+		addr.Swap(complex(1, 1))
+	}
+}
+
+func BenchmarkCompareAndSwapComplex128(b *testing.B) {
+	addr := NewComplex128(complex(1, 1))
+	for i := 0; i < b.N; i++ {
+		addr.CompareAndSwap(complex(1, 1), complex(2, 2))
+	}
+}
+
+func BenchmarkAddComplex128(b *testing.B) {
+	addr := NewComplex128(complex(1, 1))
+	for i := 0; i < b.N; i++ {
+		addr.Add(complex(1, 1))
+	}
+}
+
+func BenchmarkStoreComplex128(b *testing.B) {
+	addr := NewComplex128(complex(1, 1))
+	for i := 0; i < b.N; i++ {
+		addr.Store(complex(1, 1))
+	}
+}
+
+func BenchmarkLoadComplex128(b *testing.B) {
+	addr := NewComplex128(complex(1, 1))
+	for i := 0; i < b.N; i++ {
+		addr.Load()
+	}
+}
+
+func TestLoadComplex128Consistent(t *testing.T) {
+	addr := NewComplex128(0)
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 1; ; i++ {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			addr.Store(complex(float64(i), -float64(i)))
+		}
+	}()
+	for i := 0; i < 100000; i++ {
+		val := addr.Load()
+		if real(val) != -imag(val) {
+			t.Fatal(val)
+		}
+	}
+	close(done)
+	wg.Wait()
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2020 Meng Huang (mhboy@outlook.com)
+// This package is licensed under a MIT license that can be found in the LICENSE file.
+
+package atomic
+
+import (
+	"math"
+	"sync/atomic"
+)
+
+// Complex64 represents an complex64.
+//
+// The real part is packed into the low 32 bits and the imaginary part
+// into the high 32 bits of a single uint64.
+type Complex64 struct {
+	v uint64
+}
+
+// NewComplex64 returns a new Complex64.
+func NewComplex64(val complex64) *Complex64 {
+	addr := &Complex64{}
+	addr.Store(val)
+	return addr
+}
+
+// Swap atomically stores new into *addr and returns the previous *addr value.
+func (addr *Complex64) Swap(new complex64) (old complex64) {
+	var v = atomic.SwapUint64(&addr.v, complex64ToUint64(new))
+	return uint64ToComplex64(v)
+}
+
+// CompareAndSwap executes the compare-and-swap operation for an complex64 value.
+func (addr *Complex64) CompareAndSwap(old, new complex64) (swapped bool) {
+	return atomic.CompareAndSwapUint64(&addr.v, complex64ToUint64(old), complex64ToUint64(new))
+}
+
+// Add atomically adds delta to *addr and returns the new value.
+func (addr *Complex64) Add(delta complex64) (new complex64) {
+	for {
+		old := addr.Load()
+		new = old + delta
+		if addr.CompareAndSwap(old, new) {
+			return
+		}
+	}
+}
+
+// Update atomically replaces *addr with f(*addr) and returns the new value.
+// f may be called more than once if *addr is modified concurrently.
+func (addr *Complex64) Update(f func(old complex64) (new complex64)) (new complex64) {
+	for {
+		old := addr.Load()
+		new = f(old)
+		if addr.CompareAndSwap(old, new) {
+			return
+		}
+	}
+}
+
+// FetchAdd atomically adds delta to *addr and returns the previous value.
+func (addr *Complex64) FetchAdd(delta complex64) (old complex64) {
+	for {
+		old = addr.Load()
+		if addr.CompareAndSwap(old, old+delta) {
+			return
+		}
+	}
+}
+
+// FetchSub atomically subtracts delta from *addr and returns the previous value.
+func (addr *Complex64) FetchSub(delta complex64) (old complex64) {
+	for {
+		old = addr.Load()
+		if addr.CompareAndSwap(old, old-delta) {
+			return
+		}
+	}
+}
+
+// CompareAndExchange executes the compare-and-swap operation for an complex64 value
+// and returns the value of *addr it was compared with, which is old if the swap happened.
+// Like CompareAndSwap, it compares the bit patterns of the values.
+func (addr *Complex64) CompareAndExchange(old, new complex64) (val complex64) {
+	for {
+		if addr.CompareAndSwap(old, new) {
+			return old
+		}
+		if val = addr.Load(); complex64ToUint64(val) != complex64ToUint64(old) {
+			return
+		}
+	}
+}
+
+// Load atomically loads *addr.
+func (addr *Complex64) Load() (val complex64) {
+	return uint64ToComplex64(atomic.LoadUint64(&addr.v))
+}
+
+// Store atomically stores val into *addr.
+func (addr *Complex64) Store(val complex64) {
+	atomic.StoreUint64(&addr.v, complex64ToUint64(val))
+}
+
+func complex64ToUint64(val complex64) uint64 {
+	return uint64(math.Float32bits(real(val))) | uint64(math.Float32bits(imag(val)))<<32
+}
+
+func uint64ToComplex64(val uint64) complex64 {
+	return complex(math.Float32frombits(uint32(val)), math.Float32frombits(uint32(val>>32)))
+}
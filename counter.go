@@ -0,0 +1,31 @@
+// Copyright (c) 2020 Meng Huang (mhboy@outlook.com)
+// This package is licensed under a MIT license that can be found in the LICENSE file.
+
+package atomic
+
+// Counter represents a monotonically increasing uint64 counter.
+type Counter struct {
+	v Uint64
+}
+
+// NewCounter returns a new Counter.
+func NewCounter(val uint64) *Counter {
+	addr := &Counter{}
+	addr.v.Store(val)
+	return addr
+}
+
+// Inc atomically adds one to *addr and returns the new value.
+func (addr *Counter) Inc() (new uint64) {
+	return addr.v.Add(1)
+}
+
+// Add atomically adds delta to *addr and returns the new value.
+func (addr *Counter) Add(delta uint64) (new uint64) {
+	return addr.v.Add(delta)
+}
+
+// Load atomically loads *addr.
+func (addr *Counter) Load() (val uint64) {
+	return addr.v.Load()
+}
@@ -0,0 +1,45 @@
+// Copyright (c) 2020 Meng Huang (mhboy@outlook.com)
+// This package is licensed under a MIT license that can be found in the LICENSE file.
+
+package atomic
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestCounter(t *testing.T) {
+	addr := NewCounter(1)
+	if addr.Load() != 1 {
+		t.Error(addr.Load())
+	}
+	if addr.Inc() != 2 {
+		t.Error(addr.Load())
+	}
+	if addr.Add(3) != 5 {
+		t.Error(addr.Load())
+	}
+}
+
+func TestIncCounter(t *testing.T) {
+	addr := NewCounter(0)
+	var wg sync.WaitGroup
+	for i := 0; i < 8192; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			addr.Inc()
+		}()
+	}
+	wg.Wait()
+	if addr.Load() != 8192 {
+		t.Error(addr.Load())
+	}
+}
+
+func BenchmarkIncCounter(b *testing.B) {
+	addr := NewCounter(0)
+	for i := 0; i < b.N; i++ {
+		addr.Inc()
+	}
+}
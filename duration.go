@@ -0,0 +1,51 @@
+// Copyright (c) 2020 Meng Huang (mhboy@outlook.com)
+// This package is licensed under a MIT license that can be found in the LICENSE file.
+
+package atomic
+
+import (
+	"strconv"
+	"time"
+)
+
+// Duration represents a time.Duration.
+type Duration struct {
+	v Int64
+}
+
+// NewDuration returns a new Duration.
+func NewDuration(val time.Duration) *Duration {
+	addr := &Duration{}
+	addr.Store(val)
+	return addr
+}
+
+// Swap atomically stores new into *addr and returns the previous *addr value.
+func (addr *Duration) Swap(new time.Duration) (old time.Duration) {
+	return time.Duration(addr.v.Swap(int64(new)))
+}
+
+// CompareAndSwap executes the compare-and-swap operation for a time.Duration value.
+func (addr *Duration) CompareAndSwap(old, new time.Duration) (swapped bool) {
+	return addr.v.CompareAndSwap(int64(old), int64(new))
+}
+
+// Add atomically adds delta to *addr and returns the new value.
+func (addr *Duration) Add(delta time.Duration) (new time.Duration) {
+	return time.Duration(addr.v.Add(int64(delta)))
+}
+
+// Load atomically loads *addr.
+func (addr *Duration) Load() (val time.Duration) {
+	return time.Duration(addr.v.Load())
+}
+
+// Store atomically stores val into *addr.
+func (addr *Duration) Store(val time.Duration) {
+	addr.v.Store(int64(val))
+}
+
+// String returns *addr as a JSON string such as "1m30s".
+func (addr *Duration) String() string {
+	return strconv.Quote(addr.Load().String())
+}
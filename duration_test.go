@@ -0,0 +1,59 @@
+// Copyright (c) 2020 Meng Huang (mhboy@outlook.com)
+// This package is licensed under a MIT license that can be found in the LICENSE file.
+
+package atomic
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestDuration(t *testing.T) {
+	addr := NewDuration(time.Second)
+	if addr.Load() != time.Second {
+		t.Error(addr.Load())
+	}
+	addr.Store(2 * time.Second)
+	if addr.Load() != 2*time.Second {
+		t.Error(addr.Load())
+	}
+	if addr.Add(time.Second) != 3*time.Second {
+		t.Error(addr.Load())
+	}
+	if addr.Swap(5*time.Second) != 3*time.Second {
+		t.Error(addr.Load())
+	}
+	if !addr.CompareAndSwap(5*time.Second, time.Minute+30*time.Second) {
+		t.Error(addr.Load())
+	}
+	if addr.CompareAndSwap(5*time.Second, time.Minute) {
+		t.Error(addr.Load())
+	}
+	if addr.String() != `"1m30s"` {
+		t.Error(addr.String())
+	}
+}
+
+func TestAddDuration(t *testing.T) {
+	addr := NewDuration(0)
+	var wg sync.WaitGroup
+	for i := 0; i < 8192; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			addr.Add(time.Millisecond)
+		}()
+	}
+	wg.Wait()
+	if addr.Load() != 8192*time.Millisecond {
+		t.Error(addr.Load())
+	}
+}
+
+func BenchmarkLoadDuration(b *testing.B) {
+	addr := NewDuration(time.Second)
+	for i := 0; i < b.N; i++ {
+		addr.Load()
+	}
+}
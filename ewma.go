@@ -0,0 +1,124 @@
+// Copyright (c) 2020 Meng Huang (mhboy@outlook.com)
+// This package is licensed under a MIT license that can be found in the LICENSE file.
+
+package atomic
+
+import (
+	"math"
+	"time"
+	"unsafe"
+)
+
+// EWMA is a time-decayed exponentially weighted moving average.
+//
+// Each sample loses half of its weight every half-life, however irregularly
+// the samples arrive. The decayed sum of the samples, the decayed number of
+// samples and the time they were decayed to are kept in an immutable state
+// that Update replaces with CompareAndSwapPointer, so Value is their quotient
+// and Rate is the decayed sum per second, without a lock.
+type EWMA struct {
+	state  unsafe.Pointer
+	lambda float64
+	clock  Clock
+}
+
+// ewmaState is the state of an EWMA. It is never modified once stored.
+type ewmaState struct {
+	sum   float64
+	count float64
+	// last is the time in nanoseconds the sums were decayed to.
+	last int64
+}
+
+// NewEWMA returns a new EWMA with the given half-life.
+// If clock is nil, the system clock is used.
+func NewEWMA(halfLife time.Duration, clock Clock) *EWMA {
+	if halfLife <= 0 {
+		panic("github.com/hslam/atomic: non-positive half-life")
+	}
+	return &EWMA{lambda: math.Ln2 / float64(halfLife), clock: clockOrSystem(clock)}
+}
+
+// NewEWMAAlpha returns a new EWMA whose weights decay by 1-alpha per interval.
+// With one sample per interval, it converges to the classic EWMA with the
+// smoothing factor alpha.
+// If clock is nil, the system clock is used.
+func NewEWMAAlpha(alpha float64, interval time.Duration, clock Clock) *EWMA {
+	if alpha <= 0 || alpha >= 1 {
+		panic("github.com/hslam/atomic: alpha out of range (0, 1)")
+	}
+	if interval <= 0 {
+		panic("github.com/hslam/atomic: non-positive interval")
+	}
+	return &EWMA{lambda: -math.Log1p(-alpha) / float64(interval), clock: clockOrSystem(clock)}
+}
+
+// NewEWMA1 returns a new EWMA that decays like the 1-minute load average.
+func NewEWMA1(clock Clock) *EWMA {
+	return NewEWMAAlpha(1-math.Exp(-5.0/60), 5*time.Second, clock)
+}
+
+// NewEWMA5 returns a new EWMA that decays like the 5-minute load average.
+func NewEWMA5(clock Clock) *EWMA {
+	return NewEWMAAlpha(1-math.Exp(-5.0/60/5), 5*time.Second, clock)
+}
+
+// NewEWMA15 returns a new EWMA that decays like the 15-minute load average.
+func NewEWMA15(clock Clock) *EWMA {
+	return NewEWMAAlpha(1-math.Exp(-5.0/60/15), 5*time.Second, clock)
+}
+
+// Update atomically adds sample and returns the new average.
+// A NaN sample is ignored.
+func (e *EWMA) Update(sample float64) (avg float64) {
+	if sample != sample {
+		return e.Value()
+	}
+	now := e.clock.Now().UnixNano()
+	for {
+		old := LoadPointer(&e.state)
+		state := e.decay((*ewmaState)(old), now)
+		state.sum += sample
+		state.count++
+		if CompareAndSwapPointer(&e.state, old, unsafe.Pointer(&state)) {
+			return state.sum / state.count
+		}
+	}
+}
+
+// Value returns the average of the samples, or 0 if there are none.
+func (e *EWMA) Value() float64 {
+	state := (*ewmaState)(LoadPointer(&e.state))
+	if state == nil || state.count == 0 {
+		return 0
+	}
+	return state.sum / state.count
+}
+
+// Rate returns the decayed sum of the samples per second. If every sample is
+// a number of events, it is the moving average of the events per second.
+func (e *EWMA) Rate() float64 {
+	state := e.decay((*ewmaState)(LoadPointer(&e.state)), e.clock.Now().UnixNano())
+	return state.sum * e.lambda * float64(time.Second)
+}
+
+// Reset forgets all samples.
+func (e *EWMA) Reset() {
+	StorePointer(&e.state, nil)
+}
+
+// decay returns a copy of state decayed to now, or an empty state at now
+// if state is nil. A state after now is not decayed.
+func (e *EWMA) decay(state *ewmaState, now int64) ewmaState {
+	if state == nil {
+		return ewmaState{last: now}
+	}
+	decayed := *state
+	if now > decayed.last {
+		d := math.Exp(-e.lambda * float64(now-decayed.last))
+		decayed.sum *= d
+		decayed.count *= d
+		decayed.last = now
+	}
+	return decayed
+}
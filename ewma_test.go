@@ -0,0 +1,135 @@
+// Copyright (c) 2020 Meng Huang (mhboy@outlook.com)
+// This package is licensed under a MIT license that can be found in the LICENSE file.
+
+package atomic
+
+import (
+	"math"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestEWMA(t *testing.T) {
+	clock := newFakeClock()
+	e := NewEWMA(time.Second, clock)
+	if e.Value() != 0 || e.Rate() != 0 {
+		t.Error(e.Value(), e.Rate())
+	}
+	if e.Update(10) != 10 {
+		t.Error(e.Value())
+	}
+	if e.Update(math.NaN()) != 10 {
+		t.Error(e.Value())
+	}
+	clock.Advance(time.Second)
+	if avg := e.Update(20); math.Abs(avg-25/1.5) > 1e-9 {
+		t.Error(avg)
+	}
+	clock.Advance(-time.Second)
+	if avg := e.Update(0); math.Abs(avg-25/2.5) > 1e-9 {
+		t.Error(avg)
+	}
+	e.Reset()
+	if e.Value() != 0 {
+		t.Error(e.Value())
+	}
+}
+
+func TestEWMAAlpha(t *testing.T) {
+	clock := newFakeClock()
+	e := NewEWMAAlpha(0.5, time.Second, clock)
+	for i := 0; i < 64; i++ {
+		e.Update(0)
+		clock.Advance(time.Second)
+	}
+	if avg := e.Update(1); math.Abs(avg-0.5) > 1e-9 {
+		t.Error(avg)
+	}
+}
+
+func TestEWMARate(t *testing.T) {
+	clock := newFakeClock()
+	for _, e := range []*EWMA{NewEWMA1(clock), NewEWMA5(clock), NewEWMA15(clock)} {
+		for i := 0; i < 3*3600; i++ {
+			e.Update(5)
+			clock.Advance(time.Second)
+		}
+		if rate := e.Rate(); math.Abs(rate-5) > 0.05 {
+			t.Error(rate)
+		}
+		clock.Advance(10 * time.Hour)
+		if rate := e.Rate(); rate > 0.05 {
+			t.Error(rate)
+		}
+	}
+}
+
+func TestEWMARateReadOnly(t *testing.T) {
+	clock := newFakeClock()
+	e := NewEWMA(time.Second, clock)
+	e.Update(10)
+	state := LoadPointer(&e.state)
+	clock.Advance(time.Second)
+	if rate := e.Rate(); math.Abs(rate-5*math.Ln2) > 1e-9 {
+		t.Error(rate)
+	}
+	if LoadPointer(&e.state) != state {
+		t.Error("should not write")
+	}
+	if avg := e.Update(20); math.Abs(avg-25/1.5) > 1e-9 {
+		t.Error(avg)
+	}
+}
+
+func TestEWMAManyUpdates(t *testing.T) {
+	clock := newFakeClock()
+	e := NewEWMA15(clock)
+	// Millions of updates within a half-life, n of 1 and then n of 100.
+	const n, interval = 1 << 20, 10 * time.Microsecond
+	for _, sample := range []float64{1, 100} {
+		for i := 0; i < n; i++ {
+			e.Update(sample)
+			clock.Advance(interval)
+		}
+		if avg := e.Value(); avg < 1 || avg > 100 {
+			t.Error(avg)
+		}
+	}
+	// The n samples of 1 have decayed by d^n when the last one of 100 is added.
+	dn := math.Exp(-e.lambda * float64(n*interval))
+	if avg, want := e.Value(), (dn+100)/(dn+1); math.Abs(avg-want) > want*1e-9 {
+		t.Error(avg, want)
+	}
+}
+
+func TestUpdateEWMA(t *testing.T) {
+	e := NewEWMA(time.Second, nil)
+	var wg sync.WaitGroup
+	for i := 0; i < 8192; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			e.Update(1)
+		}()
+	}
+	wg.Wait()
+	if math.Abs(e.Value()-1) > 1e-9 {
+		t.Error(e.Value())
+	}
+}
+
+func BenchmarkUpdateEWMA(b *testing.B) {
+	e := NewEWMA(time.Second, nil)
+	for i := 0; i < b.N; i++ {
+		e.Update(1)
+	}
+}
+
+func BenchmarkValueEWMA(b *testing.B) {
+	e := NewEWMA(time.Second, nil)
+	e.Update(1)
+	for i := 0; i < b.N; i++ {
+		e.Value()
+	}
+}
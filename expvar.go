@@ -0,0 +1,190 @@
+// Copyright (c) 2020 Meng Huang (mhboy@outlook.com)
+// This package is licensed under a MIT license that can be found in the LICENSE file.
+
+package atomic
+
+import (
+	"encoding/json"
+	"expvar"
+	"math"
+	"reflect"
+	"strconv"
+)
+
+// The String methods below return JSON, so that the types satisfy expvar.Var
+// and can be published with expvar.Publish.
+
+// String returns *addr as JSON.
+func (addr *Bool) String() string {
+	return strconv.FormatBool(addr.Load())
+}
+
+// String returns *addr as JSON.
+func (addr *Int8) String() string {
+	return strconv.FormatInt(int64(addr.Load()), 10)
+}
+
+// String returns *addr as JSON.
+func (addr *Int16) String() string {
+	return strconv.FormatInt(int64(addr.Load()), 10)
+}
+
+// String returns *addr as JSON.
+func (addr *Int32) String() string {
+	return strconv.FormatInt(int64(addr.Load()), 10)
+}
+
+// String returns *addr as JSON.
+func (addr *Int64) String() string {
+	return strconv.FormatInt(addr.Load(), 10)
+}
+
+// String returns *addr as JSON.
+func (addr *Uint8) String() string {
+	return strconv.FormatUint(uint64(addr.Load()), 10)
+}
+
+// String returns *addr as JSON.
+func (addr *Uint16) String() string {
+	return strconv.FormatUint(uint64(addr.Load()), 10)
+}
+
+// String returns *addr as JSON.
+func (addr *Uint32) String() string {
+	return strconv.FormatUint(uint64(addr.Load()), 10)
+}
+
+// String returns *addr as JSON.
+func (addr *Uint64) String() string {
+	return strconv.FormatUint(addr.Load(), 10)
+}
+
+// String returns *addr as JSON.
+func (addr *Uintptr) String() string {
+	return strconv.FormatUint(uint64(addr.Load()), 10)
+}
+
+// String returns *addr as JSON. NaN and infinities, which JSON
+// cannot represent as numbers, are returned as strings.
+func (addr *Float32) String() string {
+	return formatJSONFloat(float64(addr.Load()), 32)
+}
+
+// String returns *addr as JSON. NaN and infinities, which JSON
+// cannot represent as numbers, are returned as strings.
+func (addr *Float64) String() string {
+	return formatJSONFloat(addr.Load(), 64)
+}
+
+// String returns *addr as a JSON string such as "(1+2i)".
+func (addr *Complex64) String() string {
+	return strconv.Quote(strconv.FormatComplex(complex128(addr.Load()), 'g', -1, 64))
+}
+
+// String returns *addr as a JSON string such as "(1+2i)".
+func (addr *Complex128) String() string {
+	return strconv.Quote(strconv.FormatComplex(addr.Load(), 'g', -1, 128))
+}
+
+// String returns *addr as JSON.
+func (addr *Counter) String() string {
+	return strconv.FormatUint(addr.Load(), 10)
+}
+
+// String returns the sum as JSON. NaN and infinities, which JSON
+// cannot represent as numbers, are returned as strings.
+func (addr *Sum64) String() string {
+	return formatJSONFloat(addr.Load(), 64)
+}
+
+// String returns *addr as a JSON string.
+func (addr *String) String() string {
+	b, _ := json.Marshal(addr.Load())
+	return string(b)
+}
+
+// String returns *addr as a base64 encoded JSON string, or null if it is nil.
+func (addr *Bytes) String() string {
+	b, _ := json.Marshal(addr.Load())
+	return string(b)
+}
+
+// String returns the stored value encoded by encoding/json,
+// or null if it cannot be encoded.
+func (v *Value) String() string {
+	b, err := json.Marshal(v.Load())
+	if err != nil {
+		return "null"
+	}
+	return string(b)
+}
+
+// PublishAll publishes the fields of the struct that v points to with
+// expvar.Publish. Each exported field of a type of this package that has a
+// String method is published under prefix, a dot, and the field name,
+// or the name in an `expvar:"name"` struct tag. A tag of "-" skips the
+// field, and fields of other struct types are published recursively with
+// their name added to the prefix. Types whose String method does not return
+// JSON, like Flags32, are published as JSON strings.
+// Like expvar.Publish, it panics if a name is already published.
+func PublishAll(prefix string, v interface{}) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Struct {
+		panic("github.com/hslam/atomic: PublishAll of a non-pointer to struct")
+	}
+	publishStruct(prefix, rv.Elem())
+}
+
+var atomicPkgPath = reflect.TypeOf(Value{}).PkgPath()
+
+func publishStruct(prefix string, rv reflect.Value) {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		name := field.Name
+		if tag := field.Tag.Get("expvar"); tag == "-" {
+			continue
+		} else if tag != "" {
+			name = tag
+		}
+		if prefix != "" {
+			name = prefix + "." + name
+		}
+		fv := rv.Field(i)
+		if fv.Kind() == reflect.Ptr {
+			if fv.IsNil() {
+				continue
+			}
+			fv = fv.Elem()
+		}
+		if fv.Kind() != reflect.Struct {
+			continue
+		}
+		if fv.Type().PkgPath() != atomicPkgPath {
+			publishStruct(name, fv)
+			continue
+		}
+		switch addr := fv.Addr().Interface().(type) {
+		case *Flags32, *Flags64, *StateMachine:
+			s := addr.(interface{ String() string })
+			expvar.Publish(name, expvar.Func(func() interface{} { return s.String() }))
+		case expvar.Var:
+			expvar.Publish(name, addr)
+		}
+	}
+}
+
+func formatJSONFloat(val float64, bitSize int) string {
+	switch {
+	case math.IsInf(val, 1):
+		return `"+Inf"`
+	case math.IsInf(val, -1):
+		return `"-Inf"`
+	case math.IsNaN(val):
+		return `"NaN"`
+	}
+	return strconv.FormatFloat(val, 'g', -1, bitSize)
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2020 Meng Huang (mhboy@outlook.com)
+// This package is licensed under a MIT license that can be found in the LICENSE file.
+
+package atomic
+
+import (
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestExpvarString(t *testing.T) {
+	for _, c := range []struct {
+		v    expvar.Var
+		want string
+	}{
+		{NewBool(true), `true`},
+		{NewInt8(-8), `-8`},
+		{NewInt16(-16), `-16`},
+		{NewInt32(-32), `-32`},
+		{NewInt64(-64), `-64`},
+		{NewUint8(8), `8`},
+		{NewUint16(16), `16`},
+		{NewUint32(32), `32`},
+		{NewUint64(math.MaxUint64), `18446744073709551615`},
+		{NewUintptr(1), `1`},
+		{NewFloat32(0.1), `0.1`},
+		{NewFloat64(1e21), `1e+21`},
+		{NewFloat64(math.NaN()), `"NaN"`},
+		{NewFloat64(math.Inf(1)), `"+Inf"`},
+		{NewFloat32(float32(math.Inf(-1))), `"-Inf"`},
+		{NewComplex64(complex(1, 2)), `"(1+2i)"`},
+		{NewComplex128(complex(0.5, -1)), `"(0.5-1i)"`},
+		{NewCounter(3), `3`},
+		{NewSum64(2.5), `2.5`},
+		{NewString("a\"b"), `"a\"b"`},
+		{NewBytes([]byte("hi")), `"aGk="`},
+		{NewBytes(nil), `null`},
+		{NewValue(map[string]int{"a": 1}, nil, nil), `{"a":1}`},
+		{NewValue(func() {}, nil, nil), `null`},
+		{&Value{}, `null`},
+	} {
+		got := c.v.String()
+		if got != c.want {
+			t.Errorf("%T: %s != %s", c.v, got, c.want)
+		}
+		if !json.Valid([]byte(got)) {
+			t.Errorf("%T: invalid JSON %s", c.v, got)
+		}
+	}
+}
+
+type expvarStats struct {
+	Requests Counter
+	Latency  *Float64 `expvar:"latency_ms"`
+	Missing  *Int64
+	Name     String
+	Flags    *Flags32
+	Skipped  Int64 `expvar:"-"`
+	Inner    struct {
+		Active Int32
+	}
+	Other  int
+	hidden Int64
+}
+
+// publishAllRuns makes the published names unique when the test is run more than once.
+var publishAllRuns Uint32
+
+func TestPublishAll(t *testing.T) {
+	prefix := fmt.Sprintf("atomic_test%d", publishAllRuns.Add(1))
+	stats := &expvarStats{Latency: NewFloat64(1.5), Flags: NewFlags32(1, []string{"ready"})}
+	stats.Requests.Add(7)
+	stats.Name.Store("svc")
+	stats.Inner.Active.Store(2)
+	stats.hidden.Store(1)
+	PublishAll(prefix, stats)
+	for name, want := range map[string]string{
+		"Requests":     `7`,
+		"latency_ms":   `1.5`,
+		"Name":         `"svc"`,
+		"Flags":        `"ready"`,
+		"Inner.Active": `2`,
+	} {
+		v := expvar.Get(prefix + "." + name)
+		if v == nil {
+			t.Errorf("%s is not published", name)
+			continue
+		}
+		if v.String() != want {
+			t.Errorf("%s: %s != %s", name, v.String(), want)
+		}
+	}
+	for _, name := range []string{"Missing", "Skipped", "Other", "hidden"} {
+		if expvar.Get(prefix+"."+name) != nil {
+			t.Errorf("%s should not be published", name)
+		}
+	}
+	stats.Requests.Inc()
+	if expvar.Get(prefix+".Requests").String() != `8` {
+		t.Error(expvar.Get(prefix + ".Requests").String())
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("should panic")
+		}
+	}()
+	PublishAll(prefix, *stats)
+}
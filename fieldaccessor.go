@@ -0,0 +1,248 @@
+// Copyright (c) 2020 Meng Huang (mhboy@outlook.com)
+// This package is licensed under a MIT license that can be found in the LICENSE file.
+
+package atomic
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"unsafe"
+)
+
+// ErrInvalidField is returned when a field does not exist, has the wrong
+// type or is not aligned for atomic access.
+var ErrInvalidField = errors.New("github.com/hslam/atomic: invalid field")
+
+// FieldAccessor returns atomic views of the fields of a plain struct.
+//
+// Every view is checked against the type and the alignment of the field,
+// so a view can be used where the field would otherwise be passed to the
+// functions of sync/atomic or of this package. Fields are looked up by name,
+// including promoted and unexported fields and dotted paths through nested
+// structs, or by offset. The accessor keeps the struct alive.
+type FieldAccessor struct {
+	ptr unsafe.Pointer
+	typ reflect.Type
+}
+
+// NewFieldAccessor returns a new FieldAccessor for the struct that ptr points to.
+func NewFieldAccessor(ptr interface{}) (*FieldAccessor, error) {
+	v := reflect.ValueOf(ptr)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: %T is not a pointer to struct", ErrInvalidField, ptr)
+	}
+	return &FieldAccessor{ptr: unsafe.Pointer(v.Pointer()), typ: v.Elem().Type()}, nil
+}
+
+// Int32 returns a view of the int32 field name.
+func (a *FieldAccessor) Int32(name string) (*Int32View, error) {
+	offset, t, err := a.offsetOf(name)
+	if err != nil {
+		return nil, err
+	}
+	p, err := a.field(offset, t, 4, reflect.Int32)
+	if err != nil {
+		return nil, err
+	}
+	return &Int32View{addr: (*int32)(p)}, nil
+}
+
+// Int32At returns a view of the int32 field at offset.
+func (a *FieldAccessor) Int32At(offset uintptr) (*Int32View, error) {
+	p, err := a.field(offset, nil, 4, reflect.Int32)
+	if err != nil {
+		return nil, err
+	}
+	return &Int32View{addr: (*int32)(p)}, nil
+}
+
+// Int64 returns a view of the int64 field name.
+func (a *FieldAccessor) Int64(name string) (*Int64View, error) {
+	offset, t, err := a.offsetOf(name)
+	if err != nil {
+		return nil, err
+	}
+	p, err := a.field(offset, t, 8, reflect.Int64)
+	if err != nil {
+		return nil, err
+	}
+	return &Int64View{addr: (*int64)(p)}, nil
+}
+
+// Int64At returns a view of the int64 field at offset.
+func (a *FieldAccessor) Int64At(offset uintptr) (*Int64View, error) {
+	p, err := a.field(offset, nil, 8, reflect.Int64)
+	if err != nil {
+		return nil, err
+	}
+	return &Int64View{addr: (*int64)(p)}, nil
+}
+
+// Uint32 returns a view of the uint32 field name.
+func (a *FieldAccessor) Uint32(name string) (*Uint32View, error) {
+	offset, t, err := a.offsetOf(name)
+	if err != nil {
+		return nil, err
+	}
+	p, err := a.field(offset, t, 4, reflect.Uint32)
+	if err != nil {
+		return nil, err
+	}
+	return &Uint32View{addr: (*uint32)(p)}, nil
+}
+
+// Uint32At returns a view of the uint32 field at offset.
+func (a *FieldAccessor) Uint32At(offset uintptr) (*Uint32View, error) {
+	p, err := a.field(offset, nil, 4, reflect.Uint32)
+	if err != nil {
+		return nil, err
+	}
+	return &Uint32View{addr: (*uint32)(p)}, nil
+}
+
+// Uint64 returns a view of the uint64 field name.
+func (a *FieldAccessor) Uint64(name string) (*Uint64View, error) {
+	offset, t, err := a.offsetOf(name)
+	if err != nil {
+		return nil, err
+	}
+	p, err := a.field(offset, t, 8, reflect.Uint64)
+	if err != nil {
+		return nil, err
+	}
+	return &Uint64View{addr: (*uint64)(p)}, nil
+}
+
+// Uint64At returns a view of the uint64 field at offset.
+func (a *FieldAccessor) Uint64At(offset uintptr) (*Uint64View, error) {
+	p, err := a.field(offset, nil, 8, reflect.Uint64)
+	if err != nil {
+		return nil, err
+	}
+	return &Uint64View{addr: (*uint64)(p)}, nil
+}
+
+// Uintptr returns a view of the uintptr field name.
+func (a *FieldAccessor) Uintptr(name string) (*UintptrView, error) {
+	offset, t, err := a.offsetOf(name)
+	if err != nil {
+		return nil, err
+	}
+	p, err := a.field(offset, t, unsafe.Sizeof(uintptr(0)), reflect.Uintptr)
+	if err != nil {
+		return nil, err
+	}
+	return &UintptrView{addr: (*uintptr)(p)}, nil
+}
+
+// UintptrAt returns a view of the uintptr field at offset.
+func (a *FieldAccessor) UintptrAt(offset uintptr) (*UintptrView, error) {
+	p, err := a.field(offset, nil, unsafe.Sizeof(uintptr(0)), reflect.Uintptr)
+	if err != nil {
+		return nil, err
+	}
+	return &UintptrView{addr: (*uintptr)(p)}, nil
+}
+
+// Pointer returns a view of the pointer field name.
+func (a *FieldAccessor) Pointer(name string) (*PointerView, error) {
+	offset, t, err := a.offsetOf(name)
+	if err != nil {
+		return nil, err
+	}
+	p, err := a.field(offset, t, unsafe.Sizeof(uintptr(0)), reflect.UnsafePointer, reflect.Ptr)
+	if err != nil {
+		return nil, err
+	}
+	return &PointerView{addr: (*unsafe.Pointer)(p)}, nil
+}
+
+// PointerAt returns a view of the pointer field at offset.
+func (a *FieldAccessor) PointerAt(offset uintptr) (*PointerView, error) {
+	p, err := a.field(offset, nil, unsafe.Sizeof(uintptr(0)), reflect.UnsafePointer, reflect.Ptr)
+	if err != nil {
+		return nil, err
+	}
+	return &PointerView{addr: (*unsafe.Pointer)(p)}, nil
+}
+
+// offsetOf returns the offset and the type of the field name, which may be
+// a dotted path.
+func (a *FieldAccessor) offsetOf(name string) (uintptr, reflect.Type, error) {
+	var offset uintptr
+	t := a.typ
+	for _, part := range strings.Split(name, ".") {
+		if t.Kind() != reflect.Struct {
+			return 0, nil, fmt.Errorf("%w: %s is not a struct field", ErrInvalidField, name)
+		}
+		f, ok := t.FieldByName(part)
+		if !ok {
+			return 0, nil, fmt.Errorf("%w: %s has no field %s", ErrInvalidField, a.typ, name)
+		}
+		// Walk the index, since a promoted field may be reached through
+		// an embedded pointer, which is not part of the struct.
+		for _, i := range f.Index {
+			if t.Kind() != reflect.Struct {
+				return 0, nil, fmt.Errorf("%w: %s is reached through a pointer", ErrInvalidField, name)
+			}
+			offset += t.Field(i).Offset
+			t = t.Field(i).Type
+		}
+	}
+	return offset, t, nil
+}
+
+// field returns the address of the field at offset after checking that
+// it has one of kinds and that its address is aligned to align. If t is nil,
+// the type of the field is looked up by offset.
+func (a *FieldAccessor) field(offset uintptr, t reflect.Type, align uintptr, kinds ...reflect.Kind) (unsafe.Pointer, error) {
+	var ok bool
+	if t == nil {
+		t, ok = fieldTypeAt(a.typ, offset, kinds)
+	} else {
+		ok = hasKind(t, kinds)
+	}
+	if !ok {
+		return nil, fmt.Errorf("%w: %s has no %s field at offset %d", ErrInvalidField, a.typ, kinds[0], offset)
+	}
+	p := unsafe.Pointer(uintptr(a.ptr) + offset)
+	if uintptr(p)%align != 0 {
+		return nil, fmt.Errorf("%w: %s field at offset %d of %s is not %d-byte aligned", ErrInvalidField, t, offset, a.typ, align)
+	}
+	return p, nil
+}
+
+// fieldTypeAt returns the type of the field of t at offset that has one of
+// kinds, searching nested structs and arrays.
+func fieldTypeAt(t reflect.Type, offset uintptr, kinds []reflect.Kind) (reflect.Type, bool) {
+	if offset == 0 && hasKind(t, kinds) {
+		return t, true
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if offset >= f.Offset && offset < f.Offset+f.Type.Size() {
+				if ft, ok := fieldTypeAt(f.Type, offset-f.Offset, kinds); ok {
+					return ft, true
+				}
+			}
+		}
+	case reflect.Array:
+		if size := t.Elem().Size(); size > 0 && offset < t.Size() {
+			return fieldTypeAt(t.Elem(), offset%size, kinds)
+		}
+	}
+	return nil, false
+}
+
+func hasKind(t reflect.Type, kinds []reflect.Kind) bool {
+	for _, kind := range kinds {
+		if t.Kind() == kind {
+			return true
+		}
+	}
+	return false
+}
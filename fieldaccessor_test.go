@@ -0,0 +1,144 @@
+// Copyright (c) 2020 Meng Huang (mhboy@outlook.com)
+// This package is licensed under a MIT license that can be found in the LICENSE file.
+
+package atomic
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"unsafe"
+)
+
+type legacyEmbedded struct {
+	Hits uint64
+}
+
+type legacyStats struct {
+	requests int64
+	Flags    uint32
+	State    int32
+	legacyEmbedded
+	Inner struct {
+		Errors [2]uint32
+		Addr   uintptr
+	}
+	Next  *legacyStats
+	Raw   unsafe.Pointer
+	Count int
+	*legacyPointer
+}
+
+type legacyPointer struct {
+	Behind int64
+}
+
+func TestFieldAccessor(t *testing.T) {
+	s := &legacyStats{requests: 1, Flags: 2, State: 3}
+	s.Hits = 4
+	a, err := NewFieldAccessor(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	requests, err := a.Int64("requests")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if requests.Add(1) != 2 || s.requests != 2 {
+		t.Error(requests.Load(), s.requests)
+	}
+	flags, err := a.Uint32("Flags")
+	if err != nil || flags.Swap(5) != 2 || s.Flags != 5 {
+		t.Error(err, s.Flags)
+	}
+	state, err := a.Int32At(unsafe.Offsetof(s.State))
+	if err != nil || !state.CompareAndSwap(3, 6) || s.State != 6 {
+		t.Error(err, s.State)
+	}
+	hits, err := a.Uint64("Hits")
+	if err != nil || hits.Load() != 4 {
+		t.Error(err)
+	}
+	if _, err := a.Uint32("Inner.Errors"); err == nil {
+		t.Error("an array is not an uint32")
+	}
+	errs, err := a.Uint32At(unsafe.Offsetof(s.Inner) + 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	errs.Store(7)
+	if s.Inner.Errors[1] != 7 {
+		t.Error(s.Inner.Errors)
+	}
+	addr, err := a.Uintptr("Inner.Addr")
+	if err != nil || addr.Add(8) != 8 || s.Inner.Addr != 8 {
+		t.Error(err, s.Inner.Addr)
+	}
+	next, err := a.Pointer("Next")
+	if err != nil || !next.CompareAndSwap(nil, unsafe.Pointer(s)) || s.Next != s {
+		t.Error(err, s.Next)
+	}
+	raw, err := a.PointerAt(unsafe.Offsetof(s.Raw))
+	if err != nil || raw.Swap(unsafe.Pointer(s)) != nil || raw.Load() != unsafe.Pointer(s) {
+		t.Error(err, s.Raw)
+	}
+	if v, err := a.Int64At(unsafe.Offsetof(s.requests)); err != nil || v.Load() != 2 {
+		t.Error(err)
+	}
+	if v, err := a.Uint64At(unsafe.Offsetof(s.legacyEmbedded)); err != nil {
+		t.Error(err)
+	} else {
+		v.Store(9)
+		if s.Hits != 9 {
+			t.Error(s.Hits)
+		}
+	}
+	if v, err := a.UintptrAt(unsafe.Offsetof(s.Inner) + unsafe.Offsetof(s.Inner.Addr)); err != nil || v.Load() != 8 {
+		t.Error(err)
+	}
+}
+
+func TestFieldAccessorInvalid(t *testing.T) {
+	s := &legacyStats{}
+	for _, ptr := range []interface{}{nil, *s, (*legacyStats)(nil), new(int)} {
+		if _, err := NewFieldAccessor(ptr); !errors.Is(err, ErrInvalidField) {
+			t.Errorf("%T: %v", ptr, err)
+		}
+	}
+	a, _ := NewFieldAccessor(s)
+	for _, err := range []error{
+		func() error { _, err := a.Int64("Missing"); return err }(),
+		func() error { _, err := a.Int64("Flags"); return err }(),
+		func() error { _, err := a.Int64("Count"); return err }(),
+		func() error { _, err := a.Int64("Behind"); return err }(),
+		func() error { _, err := a.Int32("Flags.X"); return err }(),
+		func() error { _, err := a.Uint64("legacyEmbedded"); return err }(),
+		func() error { _, err := a.Uint32At(unsafe.Offsetof(s.Flags) + 1); return err }(),
+		func() error { _, err := a.Int64At(unsafe.Offsetof(s.Count)); return err }(),
+		func() error { _, err := a.PointerAt(1 << 20); return err }(),
+		func() error { _, err := a.field(unsafe.Offsetof(s.Flags), nil, 64, reflect.Uint32); return err }(),
+	} {
+		if !errors.Is(err, ErrInvalidField) {
+			t.Error(err)
+		}
+	}
+}
+
+func TestFieldAccessorConcurrent(t *testing.T) {
+	s := &legacyStats{}
+	a, _ := NewFieldAccessor(s)
+	requests, _ := a.Int64("requests")
+	var wg sync.WaitGroup
+	for i := 0; i < 8192; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			requests.Add(1)
+		}()
+	}
+	wg.Wait()
+	if LoadInt64(&s.requests) != 8192 {
+		t.Error(s.requests)
+	}
+}
@@ -0,0 +1,366 @@
+// Copyright (c) 2020 Meng Huang (mhboy@outlook.com)
+// This package is licensed under a MIT license that can be found in the LICENSE file.
+
+package atomic
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"math/bits"
+	"net/http"
+	"reflect"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// The Set and Get methods below make the types implement flag.Getter, so that
+// flags defined with flag.Var can be changed safely while the program runs.
+
+// Set parses s and atomically stores it into *addr.
+func (addr *Int8) Set(s string) error {
+	val, err := strconv.ParseInt(s, 0, 8)
+	if err != nil {
+		return err
+	}
+	addr.Store(int8(val))
+	return nil
+}
+
+// Get returns the value of *addr.
+func (addr *Int8) Get() interface{} {
+	return addr.Load()
+}
+
+// Set parses s and atomically stores it into *addr.
+func (addr *Int16) Set(s string) error {
+	val, err := strconv.ParseInt(s, 0, 16)
+	if err != nil {
+		return err
+	}
+	addr.Store(int16(val))
+	return nil
+}
+
+// Get returns the value of *addr.
+func (addr *Int16) Get() interface{} {
+	return addr.Load()
+}
+
+// Set parses s and atomically stores it into *addr.
+func (addr *Int32) Set(s string) error {
+	val, err := strconv.ParseInt(s, 0, 32)
+	if err != nil {
+		return err
+	}
+	addr.Store(int32(val))
+	return nil
+}
+
+// Get returns the value of *addr.
+func (addr *Int32) Get() interface{} {
+	return addr.Load()
+}
+
+// Set parses s and atomically stores it into *addr.
+func (addr *Int64) Set(s string) error {
+	val, err := strconv.ParseInt(s, 0, 64)
+	if err != nil {
+		return err
+	}
+	addr.Store(val)
+	return nil
+}
+
+// Get returns the value of *addr.
+func (addr *Int64) Get() interface{} {
+	return addr.Load()
+}
+
+// Set parses s and atomically stores it into *addr.
+func (addr *Uint8) Set(s string) error {
+	val, err := strconv.ParseUint(s, 0, 8)
+	if err != nil {
+		return err
+	}
+	addr.Store(uint8(val))
+	return nil
+}
+
+// Get returns the value of *addr.
+func (addr *Uint8) Get() interface{} {
+	return addr.Load()
+}
+
+// Set parses s and atomically stores it into *addr.
+func (addr *Uint16) Set(s string) error {
+	val, err := strconv.ParseUint(s, 0, 16)
+	if err != nil {
+		return err
+	}
+	addr.Store(uint16(val))
+	return nil
+}
+
+// Get returns the value of *addr.
+func (addr *Uint16) Get() interface{} {
+	return addr.Load()
+}
+
+// Set parses s and atomically stores it into *addr.
+func (addr *Uint32) Set(s string) error {
+	val, err := strconv.ParseUint(s, 0, 32)
+	if err != nil {
+		return err
+	}
+	addr.Store(uint32(val))
+	return nil
+}
+
+// Get returns the value of *addr.
+func (addr *Uint32) Get() interface{} {
+	return addr.Load()
+}
+
+// Set parses s and atomically stores it into *addr.
+func (addr *Uint64) Set(s string) error {
+	val, err := strconv.ParseUint(s, 0, 64)
+	if err != nil {
+		return err
+	}
+	addr.Store(val)
+	return nil
+}
+
+// Get returns the value of *addr.
+func (addr *Uint64) Get() interface{} {
+	return addr.Load()
+}
+
+// Set parses s and atomically stores it into *addr.
+func (addr *Uintptr) Set(s string) error {
+	val, err := strconv.ParseUint(s, 0, bits.UintSize)
+	if err != nil {
+		return err
+	}
+	addr.Store(uintptr(val))
+	return nil
+}
+
+// Get returns the value of *addr.
+func (addr *Uintptr) Get() interface{} {
+	return addr.Load()
+}
+
+// Set parses s and atomically stores it into *addr.
+func (addr *Float32) Set(s string) error {
+	val, err := strconv.ParseFloat(s, 32)
+	if err != nil {
+		return err
+	}
+	addr.Store(float32(val))
+	return nil
+}
+
+// Get returns the value of *addr.
+func (addr *Float32) Get() interface{} {
+	return addr.Load()
+}
+
+// Set parses s and atomically stores it into *addr.
+func (addr *Float64) Set(s string) error {
+	val, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return err
+	}
+	addr.Store(val)
+	return nil
+}
+
+// Get returns the value of *addr.
+func (addr *Float64) Get() interface{} {
+	return addr.Load()
+}
+
+// Set parses s and atomically stores it into *addr.
+func (addr *Bool) Set(s string) error {
+	val, err := strconv.ParseBool(s)
+	if err != nil {
+		return err
+	}
+	addr.Store(val)
+	return nil
+}
+
+// Get returns the value of *addr.
+func (addr *Bool) Get() interface{} {
+	return addr.Load()
+}
+
+// IsBoolFlag reports that the flag does not need a value on the command line.
+func (addr *Bool) IsBoolFlag() bool {
+	return true
+}
+
+// Set atomically stores s into *addr.
+func (addr *String) Set(s string) error {
+	addr.Store(s)
+	return nil
+}
+
+// Get returns the value of *addr.
+func (addr *String) Get() interface{} {
+	return addr.Load()
+}
+
+// Set parses s and atomically stores it into *addr.
+func (addr *Duration) Set(s string) error {
+	val, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	addr.Store(val)
+	return nil
+}
+
+// Get returns the value of *addr.
+func (addr *Duration) Get() interface{} {
+	return addr.Load()
+}
+
+// ErrInvalidFlag is returned when a flag is not defined or cannot be changed at runtime.
+var ErrInvalidFlag = errors.New("github.com/hslam/atomic: invalid flag")
+
+// ValidateFunc checks a new value of a flag before it is stored.
+type ValidateFunc func(value interface{}) error
+
+// FlagHandler is an http.Handler that lists and updates the flags of a
+// flag.FlagSet.
+//
+// Only flags whose values are types of this package can be updated, since
+// other flag.Values are not safe to change while they are used. A GET request
+// returns the flags as JSON. A POST request with form values name=value
+// parses and validates every value first, and only stores them if all of
+// them are valid.
+type FlagHandler struct {
+	mu         sync.Mutex
+	flags      *flag.FlagSet
+	validators map[string][]ValidateFunc
+}
+
+type flagInfo struct {
+	Name       string          `json:"name"`
+	Usage      string          `json:"usage"`
+	Default    string          `json:"default"`
+	Value      json.RawMessage `json:"value"`
+	Reloadable bool            `json:"reloadable"`
+}
+
+// NewFlagHandler returns a new FlagHandler for flags.
+// If flags is nil, flag.CommandLine is used.
+func NewFlagHandler(flags *flag.FlagSet) *FlagHandler {
+	if flags == nil {
+		flags = flag.CommandLine
+	}
+	return &FlagHandler{flags: flags, validators: make(map[string][]ValidateFunc)}
+}
+
+// Validate registers f to check the new values of the flag name.
+// f is called with the parsed value, as returned by flag.Getter.
+// It panics if the flag is not defined.
+func (h *FlagHandler) Validate(name string, f ValidateFunc) {
+	if h.flags.Lookup(name) == nil {
+		panic("github.com/hslam/atomic: Validate of an undefined flag " + name)
+	}
+	h.mu.Lock()
+	h.validators[name] = append(h.validators[name], f)
+	h.mu.Unlock()
+}
+
+// Set parses and validates values, a map from flag names to new values,
+// and stores all of them if they are valid. If any value is not valid,
+// no flag is changed.
+func (h *FlagHandler) Set(values map[string]string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for name, s := range values {
+		f := h.flags.Lookup(name)
+		if f == nil || !reloadable(f.Value) {
+			return fmt.Errorf("%w: %s", ErrInvalidFlag, name)
+		}
+		// Parse into a scratch value of the same type, so that
+		// nothing is stored before every value is valid.
+		scratch := reflect.New(reflect.TypeOf(f.Value).Elem()).Interface().(flag.Getter)
+		if err := scratch.Set(s); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		for _, validate := range h.validators[name] {
+			if err := validate(scratch.Get()); err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+		}
+	}
+	for name, s := range values {
+		if err := h.flags.Set(name, s); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// ServeHTTP lists the flags on GET and updates them on POST.
+func (h *FlagHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+	case http.MethodPost:
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		values := make(map[string]string, len(r.Form))
+		for name, v := range r.Form {
+			values[name] = v[len(v)-1]
+		}
+		if err := h.Set(values); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	default:
+		w.Header().Set("Allow", "GET, HEAD, POST")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(h.list())
+}
+
+func (h *FlagHandler) list() []flagInfo {
+	infos := []flagInfo{}
+	h.flags.VisitAll(func(f *flag.Flag) {
+		info := flagInfo{Name: f.Name, Usage: f.Usage, Default: f.DefValue, Reloadable: reloadable(f.Value)}
+		if info.Reloadable {
+			// The String methods of this package return JSON. A default
+			// that is a JSON string is listed as its text, so that it can
+			// be posted back.
+			var def string
+			if json.Unmarshal([]byte(f.DefValue), &def) == nil {
+				info.Default = def
+			}
+			info.Value = json.RawMessage(f.Value.String())
+		} else {
+			info.Value, _ = json.Marshal(f.Value.String())
+		}
+		infos = append(infos, info)
+	})
+	return infos
+}
+
+func reloadable(v flag.Value) bool {
+	if _, ok := v.(flag.Getter); !ok {
+		return false
+	}
+	t := reflect.TypeOf(v)
+	return t.Kind() == reflect.Ptr && t.Elem().PkgPath() == atomicPkgPath
+}
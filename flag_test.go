@@ -0,0 +1,208 @@
+// Copyright (c) 2020 Meng Huang (mhboy@outlook.com)
+// This package is licensed under a MIT license that can be found in the LICENSE file.
+
+package atomic
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestFlagValue(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+	values := map[string]flag.Getter{
+		"int8": NewInt8(0), "int16": NewInt16(0), "int32": NewInt32(0), "int64": NewInt64(0),
+		"uint8": NewUint8(0), "uint16": NewUint16(0), "uint32": NewUint32(0), "uint64": NewUint64(0),
+		"uintptr": NewUintptr(0), "float32": NewFloat32(0), "float64": NewFloat64(0),
+		"bool": NewBool(false), "string": NewString(""), "duration": NewDuration(0),
+	}
+	for name, v := range values {
+		fs.Var(v, name, "")
+	}
+	err := fs.Parse([]string{"-int8=-8", "-int16=0x10", "-int32=-32", "-int64=64",
+		"-uint8=8", "-uint16=16", "-uint32=0x20", "-uint64=18446744073709551615",
+		"-uintptr=1", "-float32=0.5", "-float64=1e3",
+		"-bool", "-string=s", "-duration=1m"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, want := range map[string]interface{}{
+		"int8": int8(-8), "int16": int16(16), "int32": int32(-32), "int64": int64(64),
+		"uint8": uint8(8), "uint16": uint16(16), "uint32": uint32(32), "uint64": uint64(18446744073709551615),
+		"uintptr": uintptr(1), "float32": float32(0.5), "float64": float64(1000),
+		"bool": true, "string": "s", "duration": time.Minute,
+	} {
+		if got := values[name].Get(); got != want {
+			t.Errorf("%s: %v != %v", name, got, want)
+		}
+	}
+	for name, s := range map[string]string{
+		"int8": "128", "int16": "x", "int32": "1.5", "int64": "",
+		"uint8": "-1", "uint16": "65536", "uint32": "x", "uint64": "x",
+		"uintptr": "x", "float32": "x", "float64": "x", "bool": "x", "duration": "1",
+	} {
+		if fs.Set(name, s) == nil {
+			t.Errorf("%s: %q should not parse", name, s)
+		}
+	}
+}
+
+func TestFlagHandlerDefault(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	values := map[string]flag.Getter{
+		"int8": NewInt8(-8), "int16": NewInt16(16), "int32": NewInt32(-32), "int64": NewInt64(64),
+		"uint8": NewUint8(8), "uint16": NewUint16(16), "uint32": NewUint32(32), "uint64": NewUint64(18446744073709551615),
+		"uintptr": NewUintptr(1), "float32": NewFloat32(float32(math.Inf(-1))), "float64": NewFloat64(math.Inf(1)),
+		"bool": NewBool(true), "string": NewString(`say "hello" <&>`), "duration": NewDuration(90 * time.Second),
+	}
+	want := make(map[string]interface{})
+	for name, v := range values {
+		fs.Var(v, name, "")
+		want[name] = v.Get()
+	}
+	if err := fs.Parse([]string{"-int8=0", "-int16=0", "-int32=0", "-int64=0",
+		"-uint8=0", "-uint16=0", "-uint32=0", "-uint64=0", "-uintptr=0", "-float32=0", "-float64=0",
+		"-bool=false", "-string=", "-duration=0"}); err != nil {
+		t.Fatal(err)
+	}
+	h := NewFlagHandler(fs)
+	defaults := make(map[string]string)
+	for _, info := range h.list() {
+		defaults[info.Name] = info.Default
+	}
+	if err := h.Set(defaults); err != nil {
+		t.Fatal(err)
+	}
+	for name, v := range values {
+		if v.Get() != want[name] {
+			t.Errorf("%s: %v != %v", name, v.Get(), want[name])
+		}
+	}
+	// A string flag keeps quotes literally.
+	if err := h.Set(map[string]string{"string": `"x"`}); err != nil || values["string"].Get() != `"x"` {
+		t.Error(err, values["string"].Get())
+	}
+	if err := fs.Set("string", `"y"`); err != nil || values["string"].Get() != `"y"` {
+		t.Error(err, values["string"].Get())
+	}
+}
+
+func newTestFlagHandler() (*FlagHandler, *Int64, *Duration, *string) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	limit := NewInt64(10)
+	timeout := NewDuration(time.Second)
+	fs.Var(limit, "limit", "request limit")
+	fs.Var(timeout, "timeout", "request timeout")
+	name := fs.String("name", "svc", "service name")
+	h := NewFlagHandler(fs)
+	h.Validate("limit", func(value interface{}) error {
+		if value.(int64) < 0 {
+			return errors.New("negative limit")
+		}
+		return nil
+	})
+	return h, limit, timeout, name
+}
+
+func TestFlagHandlerSet(t *testing.T) {
+	h, limit, timeout, _ := newTestFlagHandler()
+	if err := h.Set(map[string]string{"limit": "20", "timeout": "5s"}); err != nil {
+		t.Error(err)
+	}
+	if limit.Load() != 20 || timeout.Load() != 5*time.Second {
+		t.Error(limit.Load(), timeout.Load())
+	}
+	for _, values := range []map[string]string{
+		{"limit": "30", "timeout": "x"},
+		{"limit": "-1", "timeout": "1s"},
+	} {
+		if err := h.Set(values); err == nil {
+			t.Error(values)
+		}
+		if limit.Load() != 20 || timeout.Load() != 5*time.Second {
+			t.Error(limit.Load(), timeout.Load())
+		}
+	}
+	for _, name := range []string{"name", "missing"} {
+		if err := h.Set(map[string]string{name: "x"}); !errors.Is(err, ErrInvalidFlag) {
+			t.Error(name, err)
+		}
+	}
+	if NewFlagHandler(nil).flags != flag.CommandLine {
+		t.Error("should use flag.CommandLine")
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("should panic")
+		}
+	}()
+	h.Validate("missing", nil)
+}
+
+func TestFlagHandlerServeHTTP(t *testing.T) {
+	h, limit, _, _ := newTestFlagHandler()
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/flags", nil))
+	want := `[{"name":"limit","usage":"request limit","default":"10","value":10,"reloadable":true},` +
+		`{"name":"name","usage":"service name","default":"svc","value":"svc","reloadable":false},` +
+		`{"name":"timeout","usage":"request timeout","default":"1s","value":"1s","reloadable":true}]`
+	if w.Code != http.StatusOK || strings.TrimSpace(w.Body.String()) != want {
+		t.Error(w.Code, w.Body.String())
+	}
+	post := func(form url.Values) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/flags", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		return w
+	}
+	if w := post(url.Values{"limit": {"42"}}); w.Code != http.StatusOK {
+		t.Error(w.Code, w.Body.String())
+	}
+	var infos []flagInfo
+	if err := json.Unmarshal(w.Body.Bytes(), &infos); err != nil || len(infos) != 3 {
+		t.Error(err, infos)
+	}
+	if limit.Load() != 42 {
+		t.Error(limit.Load())
+	}
+	if w := post(url.Values{"limit": {"-5"}}); w.Code != http.StatusBadRequest || limit.Load() != 42 {
+		t.Error(w.Code, limit.Load())
+	}
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/flags", nil))
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Error(w.Code)
+	}
+}
+
+func TestFlagHandlerConcurrent(t *testing.T) {
+	h, limit, _, _ := newTestFlagHandler()
+	var wg sync.WaitGroup
+	for i := 0; i < 8192; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if i%64 == 0 {
+				h.Set(map[string]string{"limit": "1"})
+			} else {
+				limit.Load()
+			}
+		}(i)
+	}
+	wg.Wait()
+	if limit.Load() != 1 {
+		t.Error(limit.Load())
+	}
+}
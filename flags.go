@@ -0,0 +1,79 @@
+// Copyright (c) 2020 Meng Huang (mhboy@outlook.com)
+// This package is licensed under a MIT license that can be found in the LICENSE file.
+
+package atomic
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// flagNames returns the names of the bits set in val. Set bits without a
+// name are reported together as a single hexadecimal value.
+func flagNames(val uint64, names []string) []string {
+	list := []string{}
+	for i, name := range names {
+		if bit := uint64(1) << uint(i); val&bit != 0 && name != "" {
+			list = append(list, name)
+			val &^= bit
+		}
+	}
+	if val != 0 {
+		list = append(list, "0x"+strconv.FormatUint(val, 16))
+	}
+	return list
+}
+
+// formatFlags returns the names of the bits set in val separated by "|", or "0".
+func formatFlags(val uint64, names []string) string {
+	if val == 0 {
+		return "0"
+	}
+	return strings.Join(flagNames(val, names), "|")
+}
+
+// flagMask returns the mask of the named bits.
+func flagMask(list []string, names []string, bitSize int) (mask uint64, err error) {
+	for _, s := range list {
+		var found bool
+		for i, name := range names {
+			if name != "" && name == s {
+				mask |= uint64(1) << uint(i)
+				found = true
+				break
+			}
+		}
+		if found {
+			continue
+		}
+		if !strings.HasPrefix(s, "0x") {
+			return 0, fmt.Errorf("github.com/hslam/atomic: unknown flag %q", s)
+		}
+		bits, err := strconv.ParseUint(s[2:], 16, bitSize)
+		if err != nil {
+			return 0, fmt.Errorf("github.com/hslam/atomic: invalid flag %q", s)
+		}
+		mask |= bits
+	}
+	return mask, nil
+}
+
+// mustFlagMask is like flagMask but panics if a name is unknown.
+func mustFlagMask(list []string, names []string, bitSize int) uint64 {
+	mask, err := flagMask(list, names, bitSize)
+	if err != nil {
+		panic(err)
+	}
+	return mask
+}
+
+// unmarshalFlags parses a JSON array of flag names.
+func unmarshalFlags(data []byte, names []string, bitSize int) (uint64, error) {
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return 0, err
+	}
+	return flagMask(list, names, bitSize)
+}
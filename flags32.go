@@ -0,0 +1,119 @@
+// Copyright (c) 2020 Meng Huang (mhboy@outlook.com)
+// This package is licensed under a MIT license that can be found in the LICENSE file.
+
+package atomic
+
+import (
+	"encoding/json"
+)
+
+// Flags32 represents a set of up to 32 named bits stored in a Uint32.
+type Flags32 struct {
+	v     Uint32
+	names []string
+}
+
+// NewFlags32 returns a new Flags32 whose bit i is named names[i].
+// An empty name leaves the bit unnamed.
+func NewFlags32(val uint32, names []string) *Flags32 {
+	if len(names) > 32 {
+		panic("github.com/hslam/atomic: too many flag names")
+	}
+	addr := &Flags32{names: names}
+	addr.Store(val)
+	return addr
+}
+
+// Mask returns the mask of the named bits. It panics if a name is unknown.
+func (addr *Flags32) Mask(names ...string) (mask uint32) {
+	return uint32(mustFlagMask(names, addr.names, 32))
+}
+
+// Set atomically sets the bits in mask and returns the previous *addr value.
+func (addr *Flags32) Set(mask uint32) (old uint32) {
+	for {
+		old = addr.v.Load()
+		if old&mask == mask || addr.v.CompareAndSwap(old, old|mask) {
+			return
+		}
+	}
+}
+
+// Clear atomically clears the bits in mask and returns the previous *addr value.
+func (addr *Flags32) Clear(mask uint32) (old uint32) {
+	for {
+		old = addr.v.Load()
+		if old&mask == 0 || addr.v.CompareAndSwap(old, old&^mask) {
+			return
+		}
+	}
+}
+
+// Toggle atomically flips the bits in mask and returns the previous *addr value.
+func (addr *Flags32) Toggle(mask uint32) (old uint32) {
+	for {
+		old = addr.v.Load()
+		if addr.v.CompareAndSwap(old, old^mask) {
+			return
+		}
+	}
+}
+
+// Test reports whether all bits in mask are set.
+func (addr *Flags32) Test(mask uint32) bool {
+	return addr.v.Load()&mask == mask
+}
+
+// TestAndSet atomically sets the bits in mask and reports whether they were all set before.
+func (addr *Flags32) TestAndSet(mask uint32) (set bool) {
+	return addr.Set(mask)&mask == mask
+}
+
+// TestAndClear atomically clears the bits in mask and reports whether they were all set before.
+func (addr *Flags32) TestAndClear(mask uint32) (set bool) {
+	return addr.Clear(mask)&mask == mask
+}
+
+// SetIf atomically sets the bits in mask if the bits of *addr covered by mask
+// equal expected. It returns the previous *addr value and whether the bits were set.
+func (addr *Flags32) SetIf(mask, expected uint32) (old uint32, swapped bool) {
+	for {
+		old = addr.v.Load()
+		if old&mask != expected&mask {
+			return old, false
+		}
+		if addr.v.CompareAndSwap(old, old|mask) {
+			return old, true
+		}
+	}
+}
+
+// Load atomically loads *addr.
+func (addr *Flags32) Load() (val uint32) {
+	return addr.v.Load()
+}
+
+// Store atomically stores val into *addr.
+func (addr *Flags32) Store(val uint32) {
+	addr.v.Store(val)
+}
+
+// String returns the names of the set bits separated by "|".
+func (addr *Flags32) String() string {
+	return formatFlags(uint64(addr.v.Load()), addr.names)
+}
+
+// MarshalJSON encodes the set bits as a JSON array of names.
+func (addr *Flags32) MarshalJSON() ([]byte, error) {
+	return json.Marshal(flagNames(uint64(addr.v.Load()), addr.names))
+}
+
+// UnmarshalJSON stores the bits named by a JSON array of names.
+func (addr *Flags32) UnmarshalJSON(data []byte) error {
+	val, err := unmarshalFlags(data, addr.names, 32)
+	if err != nil {
+		return err
+	}
+	addr.v.Store(uint32(val))
+	return nil
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2020 Meng Huang (mhboy@outlook.com)
+// This package is licensed under a MIT license that can be found in the LICENSE file.
+
+package atomic
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+)
+
+func TestFlags32(t *testing.T) {
+	addr := NewFlags32(0, []string{"read", "write", "", "exec"})
+	read, write, exec := addr.Mask("read"), addr.Mask("write"), addr.Mask("exec")
+	if read != 1 || write != 2 || exec != 8 || addr.Mask("read", "exec") != 9 {
+		t.Error(read, write, exec)
+	}
+	if addr.String() != "0" {
+		t.Error(addr.String())
+	}
+	if addr.Set(read|write) != 0 || addr.Load() != 3 {
+		t.Error(addr.Load())
+	}
+	if !addr.Test(read|write) || addr.Test(read|exec) {
+		t.Error(addr.Load())
+	}
+	if addr.Clear(write) != 3 || addr.Load() != 1 {
+		t.Error(addr.Load())
+	}
+	if addr.Toggle(read|exec) != 1 || addr.Load() != 8 {
+		t.Error(addr.Load())
+	}
+	if addr.TestAndSet(exec) != true || addr.TestAndSet(read) != false || addr.Load() != 9 {
+		t.Error(addr.Load())
+	}
+	if addr.TestAndClear(read) != true || addr.TestAndClear(read) != false || addr.Load() != 8 {
+		t.Error(addr.Load())
+	}
+	if old, ok := addr.SetIf(read|write, read); ok || old != 8 {
+		t.Error(old, ok)
+	}
+	if old, ok := addr.SetIf(read|write, 0); !ok || old != 8 || addr.Load() != 11 {
+		t.Error(old, ok)
+	}
+	addr.Store(0x35)
+	if addr.String() != "read|0x34" {
+		t.Error(addr.String())
+	}
+	addr.Store(read | exec)
+	if addr.String() != "read|exec" {
+		t.Error(addr.String())
+	}
+}
+
+func TestFlags32JSON(t *testing.T) {
+	addr := NewFlags32(0x19, []string{"read", "write", "", "exec"})
+	data, err := json.Marshal(addr)
+	if err != nil || string(data) != `["read","exec","0x10"]` {
+		t.Error(string(data), err)
+	}
+	other := NewFlags32(0, []string{"read", "write", "", "exec"})
+	if err := json.Unmarshal(data, other); err != nil || other.Load() != 0x19 {
+		t.Error(other.Load(), err)
+	}
+	if err := json.Unmarshal([]byte(`["none"]`), other); err == nil {
+		t.Error("should fail on an unknown name")
+	}
+	if err := json.Unmarshal([]byte(`["0xg"]`), other); err == nil {
+		t.Error("should fail on an invalid value")
+	}
+	if err := json.Unmarshal([]byte(`1`), other); err == nil {
+		t.Error("should fail on a number")
+	}
+	if data, _ := NewFlags32(0, nil).MarshalJSON(); string(data) != "[]" {
+		t.Error(string(data))
+	}
+}
+
+func TestFlags32Panic(t *testing.T) {
+	for _, f := range []func(){
+		func() { NewFlags32(0, make([]string, 33)) },
+		func() { NewFlags32(0, []string{"read"}).Mask("write") },
+	} {
+		func() {
+			defer func() {
+				if err := recover(); err == nil {
+					t.Error("should panic")
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+func TestSetFlags32(t *testing.T) {
+	addr := NewFlags32(0, nil)
+	var wg sync.WaitGroup
+	for i := 0; i < 8192; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			addr.Set(1 << uint(i%32))
+		}(i)
+	}
+	wg.Wait()
+	if addr.Load() != 1<<32-1 {
+		t.Error(addr.Load())
+	}
+}
+
+func BenchmarkTestAndSetFlags32(b *testing.B) {
+	addr := NewFlags32(0, nil)
+	for i := 0; i < b.N; i++ {
+		addr.TestAndSet(1)
+		addr.TestAndClear(1)
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright (c) 2020 Meng Huang (mhboy@outlook.com)
+// This package is licensed under a MIT license that can be found in the LICENSE file.
+
+package atomic
+
+import (
+	"encoding/json"
+)
+
+// Flags64 represents a set of up to 64 named bits stored in a Uint64.
+type Flags64 struct {
+	v     Uint64
+	names []string
+}
+
+// NewFlags64 returns a new Flags64 whose bit i is named names[i].
+// An empty name leaves the bit unnamed.
+func NewFlags64(val uint64, names []string) *Flags64 {
+	if len(names) > 64 {
+		panic("github.com/hslam/atomic: too many flag names")
+	}
+	addr := &Flags64{names: names}
+	addr.Store(val)
+	return addr
+}
+
+// Mask returns the mask of the named bits. It panics if a name is unknown.
+func (addr *Flags64) Mask(names ...string) (mask uint64) {
+	return mustFlagMask(names, addr.names, 64)
+}
+
+// Set atomically sets the bits in mask and returns the previous *addr value.
+func (addr *Flags64) Set(mask uint64) (old uint64) {
+	for {
+		old = addr.v.Load()
+		if old&mask == mask || addr.v.CompareAndSwap(old, old|mask) {
+			return
+		}
+	}
+}
+
+// Clear atomically clears the bits in mask and returns the previous *addr value.
+func (addr *Flags64) Clear(mask uint64) (old uint64) {
+	for {
+		old = addr.v.Load()
+		if old&mask == 0 || addr.v.CompareAndSwap(old, old&^mask) {
+			return
+		}
+	}
+}
+
+// Toggle atomically flips the bits in mask and returns the previous *addr value.
+func (addr *Flags64) Toggle(mask uint64) (old uint64) {
+	for {
+		old = addr.v.Load()
+		if addr.v.CompareAndSwap(old, old^mask) {
+			return
+		}
+	}
+}
+
+// Test reports whether all bits in mask are set.
+func (addr *Flags64) Test(mask uint64) bool {
+	return addr.v.Load()&mask == mask
+}
+
+// TestAndSet atomically sets the bits in mask and reports whether they were all set before.
+func (addr *Flags64) TestAndSet(mask uint64) (set bool) {
+	return addr.Set(mask)&mask == mask
+}
+
+// TestAndClear atomically clears the bits in mask and reports whether they were all set before.
+func (addr *Flags64) TestAndClear(mask uint64) (set bool) {
+	return addr.Clear(mask)&mask == mask
+}
+
+// SetIf atomically sets the bits in mask if the bits of *addr covered by mask
+// equal expected. It returns the previous *addr value and whether the bits were set.
+func (addr *Flags64) SetIf(mask, expected uint64) (old uint64, swapped bool) {
+	for {
+		old = addr.v.Load()
+		if old&mask != expected&mask {
+			return old, false
+		}
+		if addr.v.CompareAndSwap(old, old|mask) {
+			return old, true
+		}
+	}
+}
+
+// Load atomically loads *addr.
+func (addr *Flags64) Load() (val uint64) {
+	return addr.v.Load()
+}
+
+// Store atomically stores val into *addr.
+func (addr *Flags64) Store(val uint64) {
+	addr.v.Store(val)
+}
+
+// String returns the names of the set bits separated by "|".
+func (addr *Flags64) String() string {
+	return formatFlags(addr.v.Load(), addr.names)
+}
+
+// MarshalJSON encodes the set bits as a JSON array of names.
+func (addr *Flags64) MarshalJSON() ([]byte, error) {
+	return json.Marshal(flagNames(addr.v.Load(), addr.names))
+}
+
+// UnmarshalJSON stores the bits named by a JSON array of names.
+func (addr *Flags64) UnmarshalJSON(data []byte) error {
+	val, err := unmarshalFlags(data, addr.names, 64)
+	if err != nil {
+		return err
+	}
+	addr.v.Store(val)
+	return nil
+}
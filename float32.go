@@ -27,28 +27,139 @@ func (addr *Float32) Swap(new float32) (old float32) {
 }
 
 // CompareAndSwap executes the compare-and-swap operation for an float32 value.
+// It compares the bit patterns of old and *addr, like CompareAndSwapBits.
 func (addr *Float32) CompareAndSwap(old, new float32) (swapped bool) {
 	return atomic.CompareAndSwapUint32(&addr.v, *(*uint32)(unsafe.Pointer(&old)), *(*uint32)(unsafe.Pointer(&new)))
 }
 
+// CompareAndSwapBits executes the compare-and-swap operation for the bit pattern of an float32 value.
+// NaNs only match if their payloads are identical, and -0 does not match +0.
+func (addr *Float32) CompareAndSwapBits(old, new uint32) (swapped bool) {
+	return atomic.CompareAndSwapUint32(&addr.v, old, new)
+}
+
+// CompareAndSwapValue executes the compare-and-swap operation for an float32 value
+// using IEEE equality, so -0 matches +0. A NaN old matches any NaN in *addr.
+func (addr *Float32) CompareAndSwapValue(old, new float32) (swapped bool) {
+	for {
+		bits := addr.LoadBits()
+		val := uint32ToFloat32(bits)
+		if val != old && (val == val || old == old) {
+			return false
+		}
+		if addr.CompareAndSwapBits(bits, float32ToUint32(new)) {
+			return true
+		}
+	}
+}
+
 // Add atomically adds delta to *addr and returns the new value.
 func (addr *Float32) Add(delta float32) (new float32) {
 	for {
-		old := addr.Load()
-		new = old + delta
-		if addr.CompareAndSwap(old, new) {
+		old := addr.LoadBits()
+		new = uint32ToFloat32(old) + delta
+		if addr.CompareAndSwapBits(old, float32ToUint32(new)) {
+			return
+		}
+	}
+}
+
+// FetchAdd atomically adds delta to *addr and returns the previous value.
+func (addr *Float32) FetchAdd(delta float32) (old float32) {
+	for {
+		bits := addr.LoadBits()
+		old = uint32ToFloat32(bits)
+		if addr.CompareAndSwapBits(bits, float32ToUint32(old+delta)) {
+			return
+		}
+	}
+}
+
+// FetchSub atomically subtracts delta from *addr and returns the previous value.
+func (addr *Float32) FetchSub(delta float32) (old float32) {
+	for {
+		bits := addr.LoadBits()
+		old = uint32ToFloat32(bits)
+		if addr.CompareAndSwapBits(bits, float32ToUint32(old-delta)) {
 			return
 		}
 	}
 }
 
+// CompareAndExchange executes the compare-and-swap operation for an float32 value
+// and returns the value of *addr it was compared with, which is old if the swap happened.
+// Like CompareAndSwap, it compares the bit patterns of the values.
+func (addr *Float32) CompareAndExchange(old, new float32) (val float32) {
+	for {
+		if addr.CompareAndSwap(old, new) {
+			return old
+		}
+		if bits := addr.LoadBits(); bits != float32ToUint32(old) {
+			return uint32ToFloat32(bits)
+		}
+	}
+}
+
+// StoreMax atomically stores val into *addr if val is greater than *addr,
+// and returns the resulting value and whether it changed.
+// NaN is treated as missing data: a NaN val never changes *addr, and a NaN
+// *addr is replaced by any other val.
+func (addr *Float32) StoreMax(val float32) (new float32, changed bool) {
+	if val != val {
+		return addr.Load(), false
+	}
+	for {
+		bits := addr.LoadBits()
+		old := uint32ToFloat32(bits)
+		if old == old && val <= old {
+			return old, false
+		}
+		if addr.CompareAndSwapBits(bits, float32ToUint32(val)) {
+			return val, true
+		}
+	}
+}
+
+// StoreMin atomically stores val into *addr if val is less than *addr,
+// and returns the resulting value and whether it changed.
+// NaN is treated as missing data: a NaN val never changes *addr, and a NaN
+// *addr is replaced by any other val.
+func (addr *Float32) StoreMin(val float32) (new float32, changed bool) {
+	if val != val {
+		return addr.Load(), false
+	}
+	for {
+		bits := addr.LoadBits()
+		old := uint32ToFloat32(bits)
+		if old == old && val >= old {
+			return old, false
+		}
+		if addr.CompareAndSwapBits(bits, float32ToUint32(val)) {
+			return val, true
+		}
+	}
+}
+
 // Load atomically loads *addr.
 func (addr *Float32) Load() (val float32) {
 	var v = atomic.LoadUint32(&addr.v)
 	return *(*float32)(unsafe.Pointer(&v))
 }
 
+// LoadBits atomically loads the bit pattern of *addr.
+func (addr *Float32) LoadBits() (val uint32) {
+	return atomic.LoadUint32(&addr.v)
+}
+
 // Store atomically stores val into *addr.
 func (addr *Float32) Store(val float32) {
 	atomic.StoreUint32(&addr.v, *(*uint32)(unsafe.Pointer(&val)))
 }
+
+func float32ToUint32(val float32) uint32 {
+	return *(*uint32)(unsafe.Pointer(&val))
+}
+
+func uint32ToFloat32(val uint32) float32 {
+	return *(*float32)(unsafe.Pointer(&val))
+}
@@ -27,28 +27,139 @@ func (addr *Float64) Swap(new float64) (old float64) {
 }
 
 // CompareAndSwap executes the compare-and-swap operation for an float64 value.
+// It compares the bit patterns of old and *addr, like CompareAndSwapBits.
 func (addr *Float64) CompareAndSwap(old, new float64) (swapped bool) {
 	return atomic.CompareAndSwapUint64(&addr.v, *(*uint64)(unsafe.Pointer(&old)), *(*uint64)(unsafe.Pointer(&new)))
 }
 
+// CompareAndSwapBits executes the compare-and-swap operation for the bit pattern of an float64 value.
+// NaNs only match if their payloads are identical, and -0 does not match +0.
+func (addr *Float64) CompareAndSwapBits(old, new uint64) (swapped bool) {
+	return atomic.CompareAndSwapUint64(&addr.v, old, new)
+}
+
+// CompareAndSwapValue executes the compare-and-swap operation for an float64 value
+// using IEEE equality, so -0 matches +0. A NaN old matches any NaN in *addr.
+func (addr *Float64) CompareAndSwapValue(old, new float64) (swapped bool) {
+	for {
+		bits := addr.LoadBits()
+		val := uint64ToFloat64(bits)
+		if val != old && (val == val || old == old) {
+			return false
+		}
+		if addr.CompareAndSwapBits(bits, float64ToUint64(new)) {
+			return true
+		}
+	}
+}
+
 // Add atomically adds delta to *addr and returns the new value.
 func (addr *Float64) Add(delta float64) (new float64) {
 	for {
-		old := addr.Load()
-		new = old + delta
-		if addr.CompareAndSwap(old, new) {
+		old := addr.LoadBits()
+		new = uint64ToFloat64(old) + delta
+		if addr.CompareAndSwapBits(old, float64ToUint64(new)) {
+			return
+		}
+	}
+}
+
+// FetchAdd atomically adds delta to *addr and returns the previous value.
+func (addr *Float64) FetchAdd(delta float64) (old float64) {
+	for {
+		bits := addr.LoadBits()
+		old = uint64ToFloat64(bits)
+		if addr.CompareAndSwapBits(bits, float64ToUint64(old+delta)) {
+			return
+		}
+	}
+}
+
+// FetchSub atomically subtracts delta from *addr and returns the previous value.
+func (addr *Float64) FetchSub(delta float64) (old float64) {
+	for {
+		bits := addr.LoadBits()
+		old = uint64ToFloat64(bits)
+		if addr.CompareAndSwapBits(bits, float64ToUint64(old-delta)) {
 			return
 		}
 	}
 }
 
+// CompareAndExchange executes the compare-and-swap operation for an float64 value
+// and returns the value of *addr it was compared with, which is old if the swap happened.
+// Like CompareAndSwap, it compares the bit patterns of the values.
+func (addr *Float64) CompareAndExchange(old, new float64) (val float64) {
+	for {
+		if addr.CompareAndSwap(old, new) {
+			return old
+		}
+		if bits := addr.LoadBits(); bits != float64ToUint64(old) {
+			return uint64ToFloat64(bits)
+		}
+	}
+}
+
+// StoreMax atomically stores val into *addr if val is greater than *addr,
+// and returns the resulting value and whether it changed.
+// NaN is treated as missing data: a NaN val never changes *addr, and a NaN
+// *addr is replaced by any other val.
+func (addr *Float64) StoreMax(val float64) (new float64, changed bool) {
+	if val != val {
+		return addr.Load(), false
+	}
+	for {
+		bits := addr.LoadBits()
+		old := uint64ToFloat64(bits)
+		if old == old && val <= old {
+			return old, false
+		}
+		if addr.CompareAndSwapBits(bits, float64ToUint64(val)) {
+			return val, true
+		}
+	}
+}
+
+// StoreMin atomically stores val into *addr if val is less than *addr,
+// and returns the resulting value and whether it changed.
+// NaN is treated as missing data: a NaN val never changes *addr, and a NaN
+// *addr is replaced by any other val.
+func (addr *Float64) StoreMin(val float64) (new float64, changed bool) {
+	if val != val {
+		return addr.Load(), false
+	}
+	for {
+		bits := addr.LoadBits()
+		old := uint64ToFloat64(bits)
+		if old == old && val >= old {
+			return old, false
+		}
+		if addr.CompareAndSwapBits(bits, float64ToUint64(val)) {
+			return val, true
+		}
+	}
+}
+
 // Load atomically loads *addr.
 func (addr *Float64) Load() (val float64) {
 	var v = atomic.LoadUint64(&addr.v)
 	return *(*float64)(unsafe.Pointer(&v))
 }
 
+// LoadBits atomically loads the bit pattern of *addr.
+func (addr *Float64) LoadBits() (val uint64) {
+	return atomic.LoadUint64(&addr.v)
+}
+
 // Store atomically stores val into *addr.
 func (addr *Float64) Store(val float64) {
 	atomic.StoreUint64(&addr.v, *(*uint64)(unsafe.Pointer(&val)))
 }
+
+func float64ToUint64(val float64) uint64 {
+	return *(*uint64)(unsafe.Pointer(&val))
+}
+
+func uint64ToFloat64(val uint64) float64 {
+	return *(*float64)(unsafe.Pointer(&val))
+}
@@ -4,6 +4,7 @@
 package atomic
 
 import (
+	"math"
 	"sync"
 	"testing"
 )
@@ -76,3 +77,132 @@ func TestSwapFloat64(t *testing.T) {
 	}
 	wg.Wait()
 }
+
+func TestFetchFloat64(t *testing.T) {
+	addr := NewFloat64(0.5)
+	if addr.FetchAdd(0.25) != 0.5 || addr.Load() != 0.75 {
+		t.Error(addr.Load())
+	}
+	if addr.FetchSub(0.5) != 0.75 || addr.Load() != 0.25 {
+		t.Error(addr.Load())
+	}
+	if addr.CompareAndExchange(0.25, 1) != 0.25 || addr.Load() != 1 {
+		t.Error(addr.Load())
+	}
+	if addr.CompareAndExchange(0.25, 2) != 1 || addr.Load() != 1 {
+		t.Error(addr.Load())
+	}
+}
+
+func TestFetchAddFloat64(t *testing.T) {
+	addr := NewFloat64(0)
+	var seen [64]Bool
+	var wg sync.WaitGroup
+	for i := 0; i < 64; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if seen[int(addr.FetchAdd(1))].Swap(true) {
+				t.Error("duplicate")
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestStoreMaxFloat64(t *testing.T) {
+	addr := NewFloat64(0.5)
+	if new, changed := addr.StoreMax(0.25); changed || new != 0.5 {
+		t.Error(new, changed)
+	}
+	if new, changed := addr.StoreMax(0.75); !changed || new != 0.75 || addr.Load() != 0.75 {
+		t.Error(new, changed)
+	}
+	if new, changed := addr.StoreMax(float64(math.NaN())); changed || new != 0.75 {
+		t.Error(new, changed)
+	}
+	addr.Store(float64(math.NaN()))
+	if new, changed := addr.StoreMax(-1); !changed || new != -1 || addr.Load() != -1 {
+		t.Error(new, changed)
+	}
+	if new, changed := addr.StoreMax(float64(math.Inf(1))); !changed || new != float64(math.Inf(1)) {
+		t.Error(new, changed)
+	}
+}
+
+func TestStoreMinFloat64(t *testing.T) {
+	addr := NewFloat64(0.5)
+	if new, changed := addr.StoreMin(0.75); changed || new != 0.5 {
+		t.Error(new, changed)
+	}
+	if new, changed := addr.StoreMin(0.25); !changed || new != 0.25 || addr.Load() != 0.25 {
+		t.Error(new, changed)
+	}
+	if new, changed := addr.StoreMin(float64(math.NaN())); changed || new != 0.25 {
+		t.Error(new, changed)
+	}
+	addr.Store(float64(math.NaN()))
+	if new, changed := addr.StoreMin(1); !changed || new != 1 || addr.Load() != 1 {
+		t.Error(new, changed)
+	}
+	if new, changed := addr.StoreMin(float64(math.Inf(-1))); !changed || new != float64(math.Inf(-1)) {
+		t.Error(new, changed)
+	}
+}
+
+func TestCompareAndSwapBitsFloat64(t *testing.T) {
+	nan := math.Float64frombits(0x7ff8000000000001)
+	addr := NewFloat64(nan)
+	if addr.LoadBits() != math.Float64bits(nan) {
+		t.Error(addr.LoadBits())
+	}
+	if addr.CompareAndSwapBits(math.Float64bits(math.Float64frombits(0x7ff8000000000002)), 1) {
+		t.Error(addr.Load())
+	}
+	if !addr.CompareAndSwapBits(math.Float64bits(nan), math.Float64bits(1)) {
+		t.Error(addr.Load())
+	}
+	addr.Store(float64(math.Copysign(0, -1)))
+	if addr.CompareAndSwap(0, 1) {
+		t.Error(addr.Load())
+	}
+}
+
+func TestCompareAndSwapValueFloat64(t *testing.T) {
+	addr := NewFloat64(math.Float64frombits(0x7ff8000000000001))
+	if addr.CompareAndSwapValue(1, 2) {
+		t.Error(addr.Load())
+	}
+	if !addr.CompareAndSwapValue(math.Float64frombits(0x7ff8000000000002), 1) {
+		t.Error(addr.Load())
+	}
+	if addr.Load() != 1 {
+		t.Error(addr.Load())
+	}
+	addr.Store(float64(math.Copysign(0, -1)))
+	if !addr.CompareAndSwapValue(0, 1) {
+		t.Error(addr.Load())
+	}
+	if addr.CompareAndSwapValue(float64(math.NaN()), 2) {
+		t.Error(addr.Load())
+	}
+}
+
+func TestAddNaNFloat64(t *testing.T) {
+	addr := NewFloat64(math.Float64frombits(0x7ff8000000000001))
+	if new := addr.Add(1); new == new {
+		t.Error(new)
+	}
+	if old := addr.FetchSub(1); old == old {
+		t.Error(old)
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 8192; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			addr.Add(1)
+		}()
+	}
+	wg.Wait()
+}
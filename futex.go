@@ -0,0 +1,66 @@
+// Copyright (c) 2020 Meng Huang (mhboy@outlook.com)
+// This package is licensed under a MIT license that can be found in the LICENSE file.
+
+package atomic
+
+import (
+	"errors"
+	"math/bits"
+	"time"
+)
+
+// ErrTimeout is returned when a wait times out.
+var ErrTimeout = errors.New("github.com/hslam/atomic: timeout")
+
+// WaitShared blocks while *addr equals old, like Wait, but also across
+// processes that share the memory of addr, such as a slot of a
+// SharedSegment. It returns ErrTimeout if *addr still equals old after
+// timeout; a negative timeout waits forever.
+//
+// On Linux it uses a futex and is woken by WakeShared from any process.
+// On other platforms it polls *addr. WaitShared may return nil before *addr
+// changes, so callers should check *addr again.
+func (addr *Uint32) WaitShared(old uint32, timeout time.Duration) error {
+	return futexWait(&addr.v, old, timeout)
+}
+
+// WakeShared wakes up to n waiters blocked in WaitShared on addr in any
+// process and returns how many were woken. Callers should change *addr
+// before calling WakeShared. Without futexes it returns 0, since polling
+// waiters notice the change by themselves.
+func (addr *Uint32) WakeShared(n int) (woken int) {
+	if n <= 0 {
+		return 0
+	}
+	return futexWake(&addr.v, n)
+}
+
+// WakeAllShared wakes all waiters blocked in WaitShared on addr in any
+// process and returns how many were woken.
+func (addr *Uint32) WakeAllShared() (woken int) {
+	return futexWake(&addr.v, -1)
+}
+
+// WaitShared blocks while the uint32 equals old, also across processes.
+// See Uint32.WaitShared.
+func (v *Uint32View) WaitShared(old uint32, timeout time.Duration) error {
+	if v.swap {
+		old = bits.ReverseBytes32(old)
+	}
+	return futexWait(v.addr, old, timeout)
+}
+
+// WakeShared wakes up to n waiters blocked in WaitShared on the uint32 in
+// any process and returns how many were woken. See Uint32.WakeShared.
+func (v *Uint32View) WakeShared(n int) (woken int) {
+	if n <= 0 {
+		return 0
+	}
+	return futexWake(v.addr, n)
+}
+
+// WakeAllShared wakes all waiters blocked in WaitShared on the uint32 in any
+// process and returns how many were woken.
+func (v *Uint32View) WakeAllShared() (woken int) {
+	return futexWake(v.addr, -1)
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 2020 Meng Huang (mhboy@outlook.com)
+// This package is licensed under a MIT license that can be found in the LICENSE file.
+
+package atomic
+
+import (
+	"math"
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+// The futex operations without FUTEX_PRIVATE_FLAG, so that waiters in other
+// processes mapping the same memory are woken too.
+const (
+	futexWaitOp = 0
+	futexWakeOp = 1
+)
+
+// futexWait sleeps while *addr equals val, for at most timeout if it is not negative.
+func futexWait(addr *uint32, val uint32, timeout time.Duration) error {
+	var ts *syscall.Timespec
+	if timeout >= 0 {
+		t := syscall.NsecToTimespec(int64(timeout))
+		ts = &t
+	}
+	_, _, errno := syscall.Syscall6(syscall.SYS_FUTEX, uintptr(unsafe.Pointer(addr)), futexWaitOp, uintptr(val), uintptr(unsafe.Pointer(ts)), 0, 0)
+	switch errno {
+	case 0, syscall.EAGAIN, syscall.EINTR:
+		// Woken, *addr did not equal val, or interrupted by a signal.
+		return nil
+	case syscall.ETIMEDOUT:
+		return ErrTimeout
+	}
+	return errno
+}
+
+// futexWake wakes up to n waiters on addr, or all of them if n is negative.
+func futexWake(addr *uint32, n int) int {
+	if n < 0 || n > math.MaxInt32 {
+		n = math.MaxInt32
+	}
+	woken, _, errno := syscall.Syscall(syscall.SYS_FUTEX, uintptr(unsafe.Pointer(addr)), futexWakeOp, uintptr(n))
+	if errno != 0 {
+		return 0
+	}
+	return int(woken)
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2020 Meng Huang (mhboy@outlook.com)
+// This package is licensed under a MIT license that can be found in the LICENSE file.
+
+package atomic
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestWakeSharedCount(t *testing.T) {
+	buf, err := syscall.Mmap(-1, 0, syscall.Getpagesize(), syscall.PROT_READ|syscall.PROT_WRITE, syscall.MAP_ANON|syscall.MAP_SHARED)
+	if err != nil {
+		t.Skip(err)
+	}
+	defer syscall.Munmap(buf)
+	v, err := Uint32At(buf, 0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan error)
+	go func() {
+		done <- v.WaitShared(0, -1)
+	}()
+	woken := 0
+	for woken == 0 {
+		time.Sleep(time.Millisecond)
+		woken = v.WakeShared(1)
+	}
+	if woken != 1 {
+		t.Error(woken)
+	}
+	if err := <-done; err != nil {
+		t.Error(err)
+	}
+}
+
+const futexChildEnv = "ATOMIC_FUTEX_CHILD"
+
+// TestWaitSharedChild is run by TestWaitSharedProcesses in child processes.
+func TestWaitSharedChild(t *testing.T) {
+	path := os.Getenv(futexChildEnv)
+	if path == "" {
+		t.Skip("run by TestWaitSharedProcesses")
+	}
+	s, err := OpenSharedSegment(path, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	gate, _ := s.Uint32("gate")
+	started, _ := s.Int64("started")
+	passed, _ := s.Int64("passed")
+	started.Add(1)
+	for gate.Load() == 0 {
+		if err := gate.WaitShared(0, -1); err != nil {
+			t.Fatal(err)
+		}
+	}
+	passed.Add(1)
+}
+
+func TestWaitSharedProcesses(t *testing.T) {
+	if os.Getenv(futexChildEnv) != "" {
+		t.Skip("child process")
+	}
+	path := filepath.Join(t.TempDir(), "segment")
+	s, err := OpenSharedSegment(path, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	gate, _ := s.Uint32("gate")
+	started, _ := s.Int64("started")
+	passed, _ := s.Int64("passed")
+	const processes = 4
+	var wg sync.WaitGroup
+	for i := 0; i < processes; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			cmd := exec.Command(os.Args[0], "-test.run=^TestWaitSharedChild$")
+			cmd.Env = append(os.Environ(), futexChildEnv+"="+path)
+			if out, err := cmd.CombinedOutput(); err != nil {
+				t.Errorf("%v: %s", err, out)
+			}
+		}()
+	}
+	for started.Load() != processes {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(10 * time.Millisecond)
+	if passed.Load() != 0 {
+		t.Error("children passed the closed gate", passed.Load())
+	}
+	gate.Store(1)
+	gate.WakeAllShared()
+	wg.Wait()
+	if passed.Load() != processes {
+		t.Error(passed.Load())
+	}
+}
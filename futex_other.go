@@ -0,0 +1,45 @@
+// Copyright (c) 2020 Meng Huang (mhboy@outlook.com)
+// This package is licensed under a MIT license that can be found in the LICENSE file.
+
+//go:build !linux
+// +build !linux
+
+package atomic
+
+import (
+	"time"
+)
+
+// maxPollInterval bounds the sleep between two polls of futexWait.
+const maxPollInterval = 10 * time.Millisecond
+
+// futexWait polls *addr until it does not equal val, for at most timeout if
+// it is not negative.
+func futexWait(addr *uint32, val uint32, timeout time.Duration) error {
+	var deadline time.Time
+	if timeout >= 0 {
+		deadline = time.Now().Add(timeout)
+	}
+	interval := time.Microsecond
+	for LoadUint32(addr) == val {
+		if timeout >= 0 {
+			remaining := time.Until(deadline)
+			if remaining <= 0 {
+				return ErrTimeout
+			}
+			if interval > remaining {
+				interval = remaining
+			}
+		}
+		time.Sleep(interval)
+		if interval *= 2; interval > maxPollInterval {
+			interval = maxPollInterval
+		}
+	}
+	return nil
+}
+
+// futexWake returns 0, since the polling waiters wake up by themselves.
+func futexWake(addr *uint32, n int) int {
+	return 0
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2020 Meng Huang (mhboy@outlook.com)
+// This package is licensed under a MIT license that can be found in the LICENSE file.
+
+package atomic
+
+import (
+	"encoding/binary"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWaitShared(t *testing.T) {
+	addr := NewUint32(1)
+	if err := addr.WaitShared(0, -1); err != nil {
+		t.Error(err)
+	}
+	if err := addr.WaitShared(1, 0); err != ErrTimeout {
+		t.Error(err)
+	}
+	start := time.Now()
+	if err := addr.WaitShared(1, 10*time.Millisecond); err != ErrTimeout {
+		t.Error(err)
+	}
+	if time.Since(start) < 10*time.Millisecond {
+		t.Error(time.Since(start))
+	}
+	if addr.WakeShared(0) != 0 {
+		t.Error("should wake nobody")
+	}
+}
+
+func TestWakeShared(t *testing.T) {
+	addr := NewUint32(0)
+	var wg sync.WaitGroup
+	for i := 0; i < 64; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for addr.Load() == 0 {
+				if err := addr.WaitShared(0, -1); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+	}
+	time.Sleep(10 * time.Millisecond)
+	addr.Store(1)
+	addr.WakeAllShared()
+	wg.Wait()
+}
+
+func TestWaitSharedView(t *testing.T) {
+	buf := make([]byte, 8)
+	for _, order := range []binary.ByteOrder{nil, binary.LittleEndian, binary.BigEndian} {
+		v, err := Uint32At(buf, 4, order)
+		if err != nil {
+			t.Fatal(err)
+		}
+		v.Store(0x01020304)
+		if err := v.WaitShared(0x01020304, time.Millisecond); err != ErrTimeout {
+			t.Error(order, err)
+		}
+		if err := v.WaitShared(0x04030201, -1); err != nil {
+			t.Error(order, err)
+		}
+		if v.WakeShared(0) != 0 {
+			t.Error("should wake nobody")
+		}
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for v.Load() == 0x01020304 {
+				v.WaitShared(0x01020304, -1)
+			}
+		}()
+		time.Sleep(time.Millisecond)
+		v.Store(0)
+		v.WakeAllShared()
+		<-done
+	}
+}
+
+func BenchmarkWakeShared(b *testing.B) {
+	addr := NewUint32(0)
+	for i := 0; i < b.N; i++ {
+		addr.WakeShared(1)
+	}
+}
@@ -0,0 +1,247 @@
+// Copyright (c) 2020 Meng Huang (mhboy@outlook.com)
+// This package is licensed under a MIT license that can be found in the LICENSE file.
+
+package atomic
+
+import (
+	"math"
+	"math/bits"
+	"runtime"
+	"sync"
+)
+
+// Histogram is a lock-free histogram of uint64 values such as latencies.
+//
+// The buckets are log-linear: values below 2^precision are counted exactly,
+// and larger values share a bucket with the values that have the same
+// precision leading bits, so the relative error is at most 2^(1-precision).
+// The counts live in one of two buffers. Record only touches atomic
+// counters of the active buffer, while Reset switches the buffers and waits
+// for the records in flight before it drains the previous one, so no
+// concurrent record is lost.
+type Histogram struct {
+	active    Uint32
+	precision uint
+	bufs      [2]*histogramBuffer
+	mu        sync.Mutex
+}
+
+type histogramBuffer struct {
+	inflight Int64
+	sum      Uint64
+	min      Uint64
+	max      Uint64
+	counts   []Uint64
+}
+
+// NewHistogram returns a new Histogram keeping precision leading bits of
+// each value. precision must be in the range [1, 14]; every increment
+// doubles the memory used by the buckets.
+func NewHistogram(precision int) *Histogram {
+	if precision < 1 || precision > 14 {
+		panic("github.com/hslam/atomic: precision out of range [1, 14]")
+	}
+	h := &Histogram{precision: uint(precision)}
+	n := histogramBuckets(h.precision)
+	for i := range h.bufs {
+		h.bufs[i] = &histogramBuffer{counts: make([]Uint64, n)}
+		h.bufs[i].min.Store(math.MaxUint64)
+	}
+	return h
+}
+
+// Record adds value to the histogram.
+func (h *Histogram) Record(value uint64) {
+	h.RecordN(value, 1)
+}
+
+// RecordN adds n occurrences of value to the histogram.
+func (h *Histogram) RecordN(value uint64, n uint64) {
+	if n == 0 {
+		return
+	}
+	idx := histogramIndex(value, h.precision)
+	for {
+		active := h.active.Load()
+		b := h.bufs[active]
+		b.inflight.Add(1)
+		if h.active.Load() == active {
+			b.counts[idx].Add(n)
+			b.sum.Add(value * n)
+			b.min.StoreMin(value)
+			b.max.StoreMax(value)
+			b.inflight.Add(-1)
+			return
+		}
+		// Reset switched the buffers; retry on the new one.
+		b.inflight.Add(-1)
+	}
+}
+
+// Snapshot returns a copy of the counts recorded since the last Reset.
+// Records that happen during the copy may or may not be included.
+func (h *Histogram) Snapshot() *HistogramSnapshot {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.bufs[h.active.Load()].snapshot(h.precision)
+}
+
+// Reset atomically clears the histogram and returns a snapshot of the counts
+// recorded since the last Reset.
+func (h *Histogram) Reset() *HistogramSnapshot {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	old := h.active.Load()
+	h.active.Store(1 - old)
+	b := h.bufs[old]
+	for b.inflight.Load() != 0 {
+		runtime.Gosched()
+	}
+	s := b.snapshot(h.precision)
+	for i := range b.counts {
+		b.counts[i].Store(0)
+	}
+	b.sum.Store(0)
+	b.min.Store(math.MaxUint64)
+	b.max.Store(0)
+	return s
+}
+
+func (b *histogramBuffer) snapshot(precision uint) *HistogramSnapshot {
+	s := &HistogramSnapshot{
+		precision: precision,
+		counts:    make([]uint64, len(b.counts)),
+		sum:       b.sum.Load(),
+		min:       b.min.Load(),
+		max:       b.max.Load(),
+	}
+	for i := range b.counts {
+		s.counts[i] = b.counts[i].Load()
+		s.count += s.counts[i]
+	}
+	return s
+}
+
+// HistogramSnapshot is a point-in-time copy of a Histogram.
+type HistogramSnapshot struct {
+	precision uint
+	counts    []uint64
+	count     uint64
+	sum       uint64
+	min       uint64
+	max       uint64
+}
+
+// Count returns the number of recorded values.
+func (s *HistogramSnapshot) Count() uint64 {
+	return s.count
+}
+
+// Sum returns the sum of the recorded values.
+func (s *HistogramSnapshot) Sum() uint64 {
+	return s.sum
+}
+
+// Mean returns the mean of the recorded values, or 0 if there are none.
+func (s *HistogramSnapshot) Mean() float64 {
+	if s.count == 0 {
+		return 0
+	}
+	return float64(s.sum) / float64(s.count)
+}
+
+// Min returns the smallest recorded value, or 0 if there are none.
+func (s *HistogramSnapshot) Min() uint64 {
+	if s.count == 0 {
+		return 0
+	}
+	return s.min
+}
+
+// Max returns the largest recorded value, or 0 if there are none.
+func (s *HistogramSnapshot) Max() uint64 {
+	return s.max
+}
+
+// Percentile returns the value below or equal to which p percent of the
+// recorded values fall, within the precision of the histogram.
+// p is clamped to the range [0, 100].
+func (s *HistogramSnapshot) Percentile(p float64) uint64 {
+	if s.count == 0 {
+		return 0
+	}
+	if p <= 0 {
+		return s.Min()
+	}
+	if p > 100 {
+		p = 100
+	}
+	rank := uint64(math.Ceil(p / 100 * float64(s.count)))
+	if rank < 1 {
+		rank = 1
+	}
+	var total uint64
+	for i, c := range s.counts {
+		total += c
+		if total >= rank {
+			val := histogramUpper(i, s.precision)
+			if val > s.max {
+				val = s.max
+			}
+			if val < s.min {
+				val = s.min
+			}
+			return val
+		}
+	}
+	return s.max
+}
+
+// Merge adds the counts of other to s.
+// It panics if the snapshots have different precisions.
+func (s *HistogramSnapshot) Merge(other *HistogramSnapshot) {
+	if s.precision != other.precision {
+		panic("github.com/hslam/atomic: Merge of histograms with different precisions")
+	}
+	if other.count == 0 {
+		return
+	}
+	if s.count == 0 || other.min < s.min {
+		s.min = other.min
+	}
+	if other.max > s.max {
+		s.max = other.max
+	}
+	for i, c := range other.counts {
+		s.counts[i] += c
+	}
+	s.count += other.count
+	s.sum += other.sum
+}
+
+// histogramBuckets returns the number of buckets for the precision.
+func histogramBuckets(precision uint) int {
+	return 1<<precision + (64-int(precision))<<(precision-1)
+}
+
+// histogramIndex returns the bucket of value.
+func histogramIndex(value uint64, precision uint) int {
+	if value < 1<<precision {
+		return int(value)
+	}
+	shift := uint(bits.Len64(value)) - precision
+	half := uint64(1) << (precision - 1)
+	return int(1<<precision + uint64(shift-1)*half + value>>shift - half)
+}
+
+// histogramUpper returns the largest value in bucket idx.
+func histogramUpper(idx int, precision uint) uint64 {
+	if idx < 1<<precision {
+		return uint64(idx)
+	}
+	k := uint64(idx - 1<<precision)
+	half := uint64(1) << (precision - 1)
+	shift := uint(k/half) + 1
+	lo := (k%half + half) << shift
+	return lo + (1<<shift - 1)
+}
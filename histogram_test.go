@@ -0,0 +1,134 @@
+// Copyright (c) 2020 Meng Huang (mhboy@outlook.com)
+// This package is licensed under a MIT license that can be found in the LICENSE file.
+
+package atomic
+
+import (
+	"math"
+	"sync"
+	"testing"
+)
+
+func TestHistogram(t *testing.T) {
+	h := NewHistogram(7)
+	s := h.Snapshot()
+	if s.Count() != 0 || s.Min() != 0 || s.Max() != 0 || s.Mean() != 0 || s.Percentile(50) != 0 {
+		t.Error(s.Count(), s.Min(), s.Max(), s.Mean(), s.Percentile(50))
+	}
+	for i := uint64(1); i <= 1000; i++ {
+		h.Record(i)
+	}
+	h.RecordN(1000, 0)
+	s = h.Snapshot()
+	if s.Count() != 1000 || s.Sum() != 500500 || s.Mean() != 500.5 {
+		t.Error(s.Count(), s.Sum(), s.Mean())
+	}
+	if s.Min() != 1 || s.Max() != 1000 {
+		t.Error(s.Min(), s.Max())
+	}
+	if s.Percentile(0) != 1 || s.Percentile(100) != 1000 || s.Percentile(200) != 1000 {
+		t.Error(s.Percentile(0), s.Percentile(100), s.Percentile(200))
+	}
+	for _, p := range []float64{1, 10, 50, 90, 99, 99.9} {
+		if v := float64(s.Percentile(p)); math.Abs(v-p*10) > p*10/64 {
+			t.Error(p, v)
+		}
+	}
+}
+
+func TestHistogramIndex(t *testing.T) {
+	for _, precision := range []uint{1, 7, 14} {
+		n := histogramBuckets(precision)
+		if histogramIndex(math.MaxUint64, precision) != n-1 {
+			t.Error(precision, histogramIndex(math.MaxUint64, precision), n)
+		}
+		if histogramUpper(n-1, precision) != math.MaxUint64 {
+			t.Error(precision, histogramUpper(n-1, precision))
+		}
+		last := -1
+		for _, v := range []uint64{0, 1, 2, 3, 127, 128, 129, 1000, 1 << 20, 1<<20 + 12345, 1 << 40, 1 << 63} {
+			idx := histogramIndex(v, precision)
+			if idx < last || histogramUpper(idx, precision) < v {
+				t.Error(precision, v, idx)
+			}
+			if idx > 0 && histogramUpper(idx-1, precision) >= v {
+				t.Error(precision, v, idx)
+			}
+			if upper := histogramUpper(idx, precision); float64(upper-v) > float64(v)/float64(uint64(1)<<(precision-1)) {
+				t.Error(precision, v, upper)
+			}
+			last = idx
+		}
+	}
+}
+
+func TestHistogramMerge(t *testing.T) {
+	a, b := NewHistogram(7), NewHistogram(7)
+	a.RecordN(10, 3)
+	b.RecordN(1000, 1)
+	b.Record(5)
+	s := a.Snapshot()
+	s.Merge(b.Snapshot())
+	s.Merge(NewHistogram(7).Snapshot())
+	if s.Count() != 5 || s.Sum() != 1035 || s.Min() != 5 || s.Max() != 1000 {
+		t.Error(s.Count(), s.Sum(), s.Min(), s.Max())
+	}
+	if s.Percentile(80) != 10 {
+		t.Error(s.Percentile(80))
+	}
+	e := NewHistogram(7).Snapshot()
+	e.Merge(b.Snapshot())
+	if e.Min() != 5 {
+		t.Error(e.Min())
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("should panic")
+		}
+	}()
+	s.Merge(NewHistogram(8).Snapshot())
+}
+
+func TestHistogramReset(t *testing.T) {
+	h := NewHistogram(7)
+	var total Uint64
+	var wg sync.WaitGroup
+	for i := 0; i < 8192; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			h.Record(uint64(i))
+			if i%64 == 0 {
+				total.Add(h.Reset().Count())
+			}
+		}(i)
+	}
+	wg.Wait()
+	total.Add(h.Reset().Count())
+	if total.Load() != 8192 {
+		t.Error(total.Load())
+	}
+	if s := h.Snapshot(); s.Count() != 0 || s.Min() != 0 {
+		t.Error(s.Count(), s.Min())
+	}
+}
+
+func TestNewHistogramPanic(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("should panic")
+		}
+	}()
+	NewHistogram(0)
+}
+
+func BenchmarkRecordHistogram(b *testing.B) {
+	h := NewHistogram(7)
+	b.RunParallel(func(pb *testing.PB) {
+		var i uint64
+		for pb.Next() {
+			h.Record(i)
+			i++
+		}
+	})
+}
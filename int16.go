@@ -4,6 +4,7 @@
 package atomic
 
 import (
+	"math"
 	"sync/atomic"
 )
 
@@ -41,6 +42,89 @@ func (addr *Int16) Add(delta int16) (new int16) {
 	}
 }
 
+// FetchAdd atomically adds delta to *addr and returns the previous value.
+func (addr *Int16) FetchAdd(delta int16) (old int16) {
+	for {
+		old = addr.Load()
+		if addr.CompareAndSwap(old, old+delta) {
+			return
+		}
+	}
+}
+
+// FetchSub atomically subtracts delta from *addr and returns the previous value.
+func (addr *Int16) FetchSub(delta int16) (old int16) {
+	for {
+		old = addr.Load()
+		if addr.CompareAndSwap(old, old-delta) {
+			return
+		}
+	}
+}
+
+// FetchAnd atomically replaces *addr with the bitwise AND of *addr and mask
+// and returns the previous value.
+func (addr *Int16) FetchAnd(mask int16) (old int16) {
+	for {
+		old = addr.Load()
+		if addr.CompareAndSwap(old, old&mask) {
+			return
+		}
+	}
+}
+
+// FetchOr atomically replaces *addr with the bitwise OR of *addr and mask
+// and returns the previous value.
+func (addr *Int16) FetchOr(mask int16) (old int16) {
+	for {
+		old = addr.Load()
+		if addr.CompareAndSwap(old, old|mask) {
+			return
+		}
+	}
+}
+
+// CompareAndExchange executes the compare-and-swap operation for an int16 value
+// and returns the value of *addr it was compared with, which is old if the swap happened.
+func (addr *Int16) CompareAndExchange(old, new int16) (val int16) {
+	for {
+		if addr.CompareAndSwap(old, new) {
+			return old
+		}
+		if val = addr.Load(); val != old {
+			return
+		}
+	}
+}
+
+// StoreMax atomically stores val into *addr if val is greater than *addr,
+// and returns the resulting value and whether it changed.
+func (addr *Int16) StoreMax(val int16) (new int16, changed bool) {
+	for {
+		old := addr.Load()
+		if val <= old {
+			return old, false
+		}
+		if addr.CompareAndSwap(old, val) {
+			return val, true
+		}
+	}
+}
+
+// StoreMin atomically stores val into *addr if val is less than *addr,
+// and returns the resulting value and whether it changed.
+func (addr *Int16) StoreMin(val int16) (new int16, changed bool) {
+	for {
+		old := addr.Load()
+		if val >= old {
+			return old, false
+		}
+		if addr.CompareAndSwap(old, val) {
+			return val, true
+		}
+	}
+}
+
 // Load atomically loads *addr.
 func (addr *Int16) Load() (val int16) {
 	var v = atomic.LoadUint32(&addr.v)
@@ -51,3 +135,73 @@ func (addr *Int16) Load() (val int16) {
 func (addr *Int16) Store(val int16) {
 	atomic.StoreUint32(&addr.v, uint32(val))
 }
+
+// AddSaturating atomically adds delta to *addr, clamping the result to the
+// range of int16, and returns the new value.
+func (addr *Int16) AddSaturating(delta int16) (new int16) {
+	for {
+		old := addr.Load()
+		val, _ := addInt64(int64(old), int64(delta), math.MinInt16, math.MaxInt16)
+		new = int16(val)
+		if new == old || addr.CompareAndSwap(old, new) {
+			return
+		}
+	}
+}
+
+// AddChecked atomically adds delta to *addr and returns the new value.
+// If the result overflows int16, *addr is left unchanged and ok is false.
+func (addr *Int16) AddChecked(delta int16) (new int16, ok bool) {
+	return addr.AddBounded(delta, math.MinInt16, math.MaxInt16)
+}
+
+// AddBounded atomically adds delta to *addr and returns the new value.
+// If the result falls outside [lo, hi], *addr is left unchanged and ok is false.
+func (addr *Int16) AddBounded(delta, lo, hi int16) (new int16, ok bool) {
+	for {
+		old := addr.Load()
+		val, ok := addInt64(int64(old), int64(delta), int64(lo), int64(hi))
+		if !ok {
+			return old, false
+		}
+		new = int16(val)
+		if addr.CompareAndSwap(old, new) {
+			return new, true
+		}
+	}
+}
+
+// SubSaturating atomically subtracts delta from *addr, clamping the result to the
+// range of int16, and returns the new value.
+func (addr *Int16) SubSaturating(delta int16) (new int16) {
+	for {
+		old := addr.Load()
+		val, _ := subInt64(int64(old), int64(delta), math.MinInt16, math.MaxInt16)
+		new = int16(val)
+		if new == old || addr.CompareAndSwap(old, new) {
+			return
+		}
+	}
+}
+
+// SubChecked atomically subtracts delta from *addr and returns the new value.
+// If the result overflows int16, *addr is left unchanged and ok is false.
+func (addr *Int16) SubChecked(delta int16) (new int16, ok bool) {
+	return addr.SubBounded(delta, math.MinInt16, math.MaxInt16)
+}
+
+// SubBounded atomically subtracts delta from *addr and returns the new value.
+// If the result falls outside [lo, hi], *addr is left unchanged and ok is false.
+func (addr *Int16) SubBounded(delta, lo, hi int16) (new int16, ok bool) {
+	for {
+		old := addr.Load()
+		val, ok := subInt64(int64(old), int64(delta), int64(lo), int64(hi))
+		if !ok {
+			return old, false
+		}
+		new = int16(val)
+		if addr.CompareAndSwap(old, new) {
+			return new, true
+		}
+	}
+}
@@ -4,6 +4,7 @@
 package atomic
 
 import (
+	"math"
 	"sync"
 	"testing"
 )
@@ -77,6 +78,193 @@ func TestSwapInt16(t *testing.T) {
 	wg.Wait()
 }
 
+func TestSaturatingInt16(t *testing.T) {
+	addr := NewInt16(math.MaxInt16 - 1)
+	if addr.AddSaturating(2) != math.MaxInt16 || addr.Load() != math.MaxInt16 {
+		t.Error(addr.Load())
+	}
+	if addr.AddSaturating(1) != math.MaxInt16 {
+		t.Error(addr.Load())
+	}
+	if addr.SubSaturating(-1) != math.MaxInt16 {
+		t.Error(addr.Load())
+	}
+	addr.Store(math.MinInt16 + 1)
+	if addr.SubSaturating(2) != math.MinInt16 || addr.Load() != math.MinInt16 {
+		t.Error(addr.Load())
+	}
+	if addr.AddSaturating(-1) != math.MinInt16 {
+		t.Error(addr.Load())
+	}
+	if addr.SubSaturating(math.MinInt16) != 0 {
+		t.Error(addr.Load())
+	}
+	addr.Store(-1)
+	if addr.SubSaturating(math.MinInt16) != math.MaxInt16 {
+		t.Error(addr.Load())
+	}
+	if addr.AddSaturating(math.MinInt16) != -1 {
+		t.Error(addr.Load())
+	}
+}
+
+func TestCheckedInt16(t *testing.T) {
+	addr := NewInt16(math.MaxInt16 - 1)
+	if new, ok := addr.AddChecked(1); !ok || new != math.MaxInt16 {
+		t.Error(new, ok)
+	}
+	if new, ok := addr.AddChecked(1); ok || new != math.MaxInt16 || addr.Load() != math.MaxInt16 {
+		t.Error(new, ok)
+	}
+	if new, ok := addr.SubChecked(-1); ok || new != math.MaxInt16 {
+		t.Error(new, ok)
+	}
+	addr.Store(math.MinInt16 + 1)
+	if new, ok := addr.SubChecked(1); !ok || new != math.MinInt16 {
+		t.Error(new, ok)
+	}
+	if new, ok := addr.SubChecked(1); ok || new != math.MinInt16 || addr.Load() != math.MinInt16 {
+		t.Error(new, ok)
+	}
+	if new, ok := addr.AddChecked(-1); ok || new != math.MinInt16 {
+		t.Error(new, ok)
+	}
+	addr.Store(0)
+	if new, ok := addr.SubChecked(math.MinInt16); ok || new != 0 {
+		t.Error(new, ok)
+	}
+}
+
+func TestBoundedInt16(t *testing.T) {
+	addr := NewInt16(0)
+	if new, ok := addr.AddBounded(3, -3, 3); !ok || new != 3 {
+		t.Error(new, ok)
+	}
+	if new, ok := addr.AddBounded(1, -3, 3); ok || new != 3 {
+		t.Error(new, ok)
+	}
+	if new, ok := addr.SubBounded(6, -3, 3); !ok || new != -3 {
+		t.Error(new, ok)
+	}
+	if new, ok := addr.SubBounded(1, -3, 3); ok || new != -3 {
+		t.Error(new, ok)
+	}
+	if new, ok := addr.AddBounded(math.MaxInt16, -3, 3); ok || new != -3 {
+		t.Error(new, ok)
+	}
+}
+
+func TestAddBoundedInt16(t *testing.T) {
+	addr := NewInt16(0)
+	var wg sync.WaitGroup
+	for i := 0; i < 8192; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			addr.AddBounded(1, 0, 100)
+		}()
+	}
+	wg.Wait()
+	if addr.Load() != 100 {
+		t.Error(addr.Load())
+	}
+}
+
+func TestFetchInt16(t *testing.T) {
+	addr := NewInt16(6)
+	if addr.FetchAdd(2) != 6 || addr.Load() != 8 {
+		t.Error(addr.Load())
+	}
+	if addr.FetchSub(3) != 8 || addr.Load() != 5 {
+		t.Error(addr.Load())
+	}
+	if addr.FetchAnd(6) != 5 || addr.Load() != 4 {
+		t.Error(addr.Load())
+	}
+	if addr.FetchOr(3) != 4 || addr.Load() != 7 {
+		t.Error(addr.Load())
+	}
+	if addr.CompareAndExchange(7, 9) != 7 || addr.Load() != 9 {
+		t.Error(addr.Load())
+	}
+	if addr.CompareAndExchange(7, 10) != 9 || addr.Load() != 9 {
+		t.Error(addr.Load())
+	}
+	addr.Store(-1)
+	if addr.FetchAnd(1) != -1 || addr.Load() != 1 {
+		t.Error(addr.Load())
+	}
+	if addr.FetchOr(-2) != 1 || addr.Load() != -1 {
+		t.Error(addr.Load())
+	}
+}
+
+func TestFetchAddInt16(t *testing.T) {
+	addr := NewInt16(0)
+	var seen [64]Bool
+	var wg sync.WaitGroup
+	for i := 0; i < 64; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if seen[addr.FetchAdd(1)].Swap(true) {
+				t.Error("duplicate")
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestStoreMaxInt16(t *testing.T) {
+	addr := NewInt16(5)
+	if new, changed := addr.StoreMax(3); changed || new != 5 {
+		t.Error(new, changed)
+	}
+	if new, changed := addr.StoreMax(5); changed || new != 5 {
+		t.Error(new, changed)
+	}
+	if new, changed := addr.StoreMax(7); !changed || new != 7 || addr.Load() != 7 {
+		t.Error(new, changed)
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i int16) {
+			defer wg.Done()
+			addr.StoreMax(i)
+		}(int16(i))
+	}
+	wg.Wait()
+	if addr.Load() != 99 {
+		t.Error(addr.Load())
+	}
+}
+
+func TestStoreMinInt16(t *testing.T) {
+	addr := NewInt16(5)
+	if new, changed := addr.StoreMin(7); changed || new != 5 {
+		t.Error(new, changed)
+	}
+	if new, changed := addr.StoreMin(5); changed || new != 5 {
+		t.Error(new, changed)
+	}
+	if new, changed := addr.StoreMin(3); !changed || new != 3 || addr.Load() != 3 {
+		t.Error(new, changed)
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i int16) {
+			defer wg.Done()
+			addr.StoreMin(i)
+		}(int16(i))
+	}
+	wg.Wait()
+	if addr.Load() != 0 {
+		t.Error(addr.Load())
+	}
+}
+
 func BenchmarkSwapInt16(b *testing.B) {
 	addr := NewInt16(1)
 	for i := 0; i < b.N; i++ {
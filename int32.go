@@ -4,7 +4,10 @@
 package atomic
 
 import (
+	"context"
+	"math"
 	"sync/atomic"
+	"unsafe"
 )
 
 // Int32 represents an int32.
@@ -34,6 +37,62 @@ func (addr *Int32) Add(delta int32) (new int32) {
 	return atomic.AddInt32(&addr.v, delta)
 }
 
+// FetchAdd atomically adds delta to *addr and returns the previous value.
+func (addr *Int32) FetchAdd(delta int32) (old int32) {
+	return FetchAddInt32(&addr.v, delta)
+}
+
+// FetchSub atomically subtracts delta from *addr and returns the previous value.
+func (addr *Int32) FetchSub(delta int32) (old int32) {
+	return FetchSubInt32(&addr.v, delta)
+}
+
+// FetchAnd atomically replaces *addr with the bitwise AND of *addr and mask
+// and returns the previous value.
+func (addr *Int32) FetchAnd(mask int32) (old int32) {
+	return FetchAndInt32(&addr.v, mask)
+}
+
+// FetchOr atomically replaces *addr with the bitwise OR of *addr and mask
+// and returns the previous value.
+func (addr *Int32) FetchOr(mask int32) (old int32) {
+	return FetchOrInt32(&addr.v, mask)
+}
+
+// CompareAndExchange executes the compare-and-swap operation for an int32 value
+// and returns the value of *addr it was compared with, which is old if the swap happened.
+func (addr *Int32) CompareAndExchange(old, new int32) (val int32) {
+	return CompareAndExchangeInt32(&addr.v, old, new)
+}
+
+// StoreMax atomically stores val into *addr if val is greater than *addr,
+// and returns the resulting value and whether it changed.
+func (addr *Int32) StoreMax(val int32) (new int32, changed bool) {
+	for {
+		old := addr.Load()
+		if val <= old {
+			return old, false
+		}
+		if addr.CompareAndSwap(old, val) {
+			return val, true
+		}
+	}
+}
+
+// StoreMin atomically stores val into *addr if val is less than *addr,
+// and returns the resulting value and whether it changed.
+func (addr *Int32) StoreMin(val int32) (new int32, changed bool) {
+	for {
+		old := addr.Load()
+		if val >= old {
+			return old, false
+		}
+		if addr.CompareAndSwap(old, val) {
+			return val, true
+		}
+	}
+}
+
 // Load atomically loads *addr.
 func (addr *Int32) Load() (val int32) {
 	return atomic.LoadInt32(&addr.v)
@@ -43,3 +102,100 @@ func (addr *Int32) Load() (val int32) {
 func (addr *Int32) Store(val int32) {
 	atomic.StoreInt32(&addr.v, val)
 }
+
+// Wait parks the calling goroutine while *addr == old until Wake or WakeAll
+// is called, and returns immediately if *addr != old. Like a futex, Wait may
+// return early, so callers must re-check *addr in a loop.
+func (addr *Int32) Wait(old int32) {
+	park(context.Background(), unsafe.Pointer(&addr.v), func() bool { return addr.Load() == old })
+}
+
+// WaitContext is like Wait but returns ctx.Err() if ctx is done before
+// the goroutine is woken.
+func (addr *Int32) WaitContext(ctx context.Context, old int32) error {
+	return park(ctx, unsafe.Pointer(&addr.v), func() bool { return addr.Load() == old })
+}
+
+// Wake wakes up to n goroutines blocked in Wait on addr and returns how many were woken.
+// Callers should change *addr before calling Wake.
+func (addr *Int32) Wake(n int) (woken int) {
+	if n <= 0 {
+		return 0
+	}
+	return unpark(unsafe.Pointer(&addr.v), n)
+}
+
+// WakeAll wakes all goroutines blocked in Wait on addr and returns how many were woken.
+func (addr *Int32) WakeAll() (woken int) {
+	return unpark(unsafe.Pointer(&addr.v), -1)
+}
+
+// AddSaturating atomically adds delta to *addr, clamping the result to the
+// range of int32, and returns the new value.
+func (addr *Int32) AddSaturating(delta int32) (new int32) {
+	for {
+		old := addr.Load()
+		val, _ := addInt64(int64(old), int64(delta), math.MinInt32, math.MaxInt32)
+		new = int32(val)
+		if new == old || addr.CompareAndSwap(old, new) {
+			return
+		}
+	}
+}
+
+// AddChecked atomically adds delta to *addr and returns the new value.
+// If the result overflows int32, *addr is left unchanged and ok is false.
+func (addr *Int32) AddChecked(delta int32) (new int32, ok bool) {
+	return addr.AddBounded(delta, math.MinInt32, math.MaxInt32)
+}
+
+// AddBounded atomically adds delta to *addr and returns the new value.
+// If the result falls outside [lo, hi], *addr is left unchanged and ok is false.
+func (addr *Int32) AddBounded(delta, lo, hi int32) (new int32, ok bool) {
+	for {
+		old := addr.Load()
+		val, ok := addInt64(int64(old), int64(delta), int64(lo), int64(hi))
+		if !ok {
+			return old, false
+		}
+		new = int32(val)
+		if addr.CompareAndSwap(old, new) {
+			return new, true
+		}
+	}
+}
+
+// SubSaturating atomically subtracts delta from *addr, clamping the result to the
+// range of int32, and returns the new value.
+func (addr *Int32) SubSaturating(delta int32) (new int32) {
+	for {
+		old := addr.Load()
+		val, _ := subInt64(int64(old), int64(delta), math.MinInt32, math.MaxInt32)
+		new = int32(val)
+		if new == old || addr.CompareAndSwap(old, new) {
+			return
+		}
+	}
+}
+
+// SubChecked atomically subtracts delta from *addr and returns the new value.
+// If the result overflows int32, *addr is left unchanged and ok is false.
+func (addr *Int32) SubChecked(delta int32) (new int32, ok bool) {
+	return addr.SubBounded(delta, math.MinInt32, math.MaxInt32)
+}
+
+// SubBounded atomically subtracts delta from *addr and returns the new value.
+// If the result falls outside [lo, hi], *addr is left unchanged and ok is false.
+func (addr *Int32) SubBounded(delta, lo, hi int32) (new int32, ok bool) {
+	for {
+		old := addr.Load()
+		val, ok := subInt64(int64(old), int64(delta), int64(lo), int64(hi))
+		if !ok {
+			return old, false
+		}
+		new = int32(val)
+		if addr.CompareAndSwap(old, new) {
+			return new, true
+		}
+	}
+}
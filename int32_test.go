@@ -4,8 +4,12 @@
 package atomic
 
 import (
+	"context"
+	"math"
 	"sync"
 	"testing"
+	"time"
+	"unsafe"
 )
 
 func TestInt32(t *testing.T) {
@@ -77,6 +81,270 @@ func TestSwapInt32(t *testing.T) {
 	wg.Wait()
 }
 
+func TestWaitInt32(t *testing.T) {
+	addr := NewInt32(0)
+	addr.Wait(1)
+	var wg sync.WaitGroup
+	for i := 0; i < 64; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for addr.Load() == 0 {
+				addr.Wait(0)
+			}
+		}()
+	}
+	time.Sleep(time.Millisecond * 10)
+	addr.Store(1)
+	addr.WakeAll()
+	wg.Wait()
+	if addr.Wake(0) != 0 {
+		t.Error("should not wake")
+	}
+}
+
+func TestWakeInt32(t *testing.T) {
+	addr := NewInt32(0)
+	woken := make(chan struct{}, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			addr.Wait(0)
+			woken <- struct{}{}
+		}()
+	}
+	for {
+		b := waitBucketOf(unsafe.Pointer(&addr.v))
+		b.mu.Lock()
+		parked := b.head != nil && b.head.next != nil
+		b.mu.Unlock()
+		if parked {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	addr.Store(1)
+	if n := addr.Wake(1); n != 1 {
+		t.Error(n)
+	}
+	<-woken
+	if n := addr.Wake(2); n != 1 {
+		t.Error(n)
+	}
+	<-woken
+}
+
+func TestWaitContextInt32(t *testing.T) {
+	addr := NewInt32(0)
+	if err := addr.WaitContext(context.Background(), 1); err != nil {
+		t.Error(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*10)
+	defer cancel()
+	if err := addr.WaitContext(ctx, 0); err != context.DeadlineExceeded {
+		t.Error(err)
+	}
+	if addr.WakeAll() != 0 {
+		t.Error("should be removed after cancellation")
+	}
+	done := make(chan error)
+	go func() {
+		done <- addr.WaitContext(context.Background(), 0)
+	}()
+	for addr.Wake(1) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	if err := <-done; err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSaturatingInt32(t *testing.T) {
+	addr := NewInt32(math.MaxInt32 - 1)
+	if addr.AddSaturating(2) != math.MaxInt32 || addr.Load() != math.MaxInt32 {
+		t.Error(addr.Load())
+	}
+	if addr.AddSaturating(1) != math.MaxInt32 {
+		t.Error(addr.Load())
+	}
+	if addr.SubSaturating(-1) != math.MaxInt32 {
+		t.Error(addr.Load())
+	}
+	addr.Store(math.MinInt32 + 1)
+	if addr.SubSaturating(2) != math.MinInt32 || addr.Load() != math.MinInt32 {
+		t.Error(addr.Load())
+	}
+	if addr.AddSaturating(-1) != math.MinInt32 {
+		t.Error(addr.Load())
+	}
+	if addr.SubSaturating(math.MinInt32) != 0 {
+		t.Error(addr.Load())
+	}
+	addr.Store(-1)
+	if addr.SubSaturating(math.MinInt32) != math.MaxInt32 {
+		t.Error(addr.Load())
+	}
+	if addr.AddSaturating(math.MinInt32) != -1 {
+		t.Error(addr.Load())
+	}
+}
+
+func TestCheckedInt32(t *testing.T) {
+	addr := NewInt32(math.MaxInt32 - 1)
+	if new, ok := addr.AddChecked(1); !ok || new != math.MaxInt32 {
+		t.Error(new, ok)
+	}
+	if new, ok := addr.AddChecked(1); ok || new != math.MaxInt32 || addr.Load() != math.MaxInt32 {
+		t.Error(new, ok)
+	}
+	if new, ok := addr.SubChecked(-1); ok || new != math.MaxInt32 {
+		t.Error(new, ok)
+	}
+	addr.Store(math.MinInt32 + 1)
+	if new, ok := addr.SubChecked(1); !ok || new != math.MinInt32 {
+		t.Error(new, ok)
+	}
+	if new, ok := addr.SubChecked(1); ok || new != math.MinInt32 || addr.Load() != math.MinInt32 {
+		t.Error(new, ok)
+	}
+	if new, ok := addr.AddChecked(-1); ok || new != math.MinInt32 {
+		t.Error(new, ok)
+	}
+	addr.Store(0)
+	if new, ok := addr.SubChecked(math.MinInt32); ok || new != 0 {
+		t.Error(new, ok)
+	}
+}
+
+func TestBoundedInt32(t *testing.T) {
+	addr := NewInt32(0)
+	if new, ok := addr.AddBounded(3, -3, 3); !ok || new != 3 {
+		t.Error(new, ok)
+	}
+	if new, ok := addr.AddBounded(1, -3, 3); ok || new != 3 {
+		t.Error(new, ok)
+	}
+	if new, ok := addr.SubBounded(6, -3, 3); !ok || new != -3 {
+		t.Error(new, ok)
+	}
+	if new, ok := addr.SubBounded(1, -3, 3); ok || new != -3 {
+		t.Error(new, ok)
+	}
+	if new, ok := addr.AddBounded(math.MaxInt32, -3, 3); ok || new != -3 {
+		t.Error(new, ok)
+	}
+}
+
+func TestAddBoundedInt32(t *testing.T) {
+	addr := NewInt32(0)
+	var wg sync.WaitGroup
+	for i := 0; i < 8192; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			addr.AddBounded(1, 0, 100)
+		}()
+	}
+	wg.Wait()
+	if addr.Load() != 100 {
+		t.Error(addr.Load())
+	}
+}
+
+func TestFetchInt32(t *testing.T) {
+	addr := NewInt32(6)
+	if addr.FetchAdd(2) != 6 || addr.Load() != 8 {
+		t.Error(addr.Load())
+	}
+	if addr.FetchSub(3) != 8 || addr.Load() != 5 {
+		t.Error(addr.Load())
+	}
+	if addr.FetchAnd(6) != 5 || addr.Load() != 4 {
+		t.Error(addr.Load())
+	}
+	if addr.FetchOr(3) != 4 || addr.Load() != 7 {
+		t.Error(addr.Load())
+	}
+	if addr.CompareAndExchange(7, 9) != 7 || addr.Load() != 9 {
+		t.Error(addr.Load())
+	}
+	if addr.CompareAndExchange(7, 10) != 9 || addr.Load() != 9 {
+		t.Error(addr.Load())
+	}
+	addr.Store(-1)
+	if addr.FetchAnd(1) != -1 || addr.Load() != 1 {
+		t.Error(addr.Load())
+	}
+	if addr.FetchOr(-2) != 1 || addr.Load() != -1 {
+		t.Error(addr.Load())
+	}
+}
+
+func TestFetchAddInt32(t *testing.T) {
+	addr := NewInt32(0)
+	var seen [64]Bool
+	var wg sync.WaitGroup
+	for i := 0; i < 64; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if seen[addr.FetchAdd(1)].Swap(true) {
+				t.Error("duplicate")
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestStoreMaxInt32(t *testing.T) {
+	addr := NewInt32(5)
+	if new, changed := addr.StoreMax(3); changed || new != 5 {
+		t.Error(new, changed)
+	}
+	if new, changed := addr.StoreMax(5); changed || new != 5 {
+		t.Error(new, changed)
+	}
+	if new, changed := addr.StoreMax(7); !changed || new != 7 || addr.Load() != 7 {
+		t.Error(new, changed)
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i int32) {
+			defer wg.Done()
+			addr.StoreMax(i)
+		}(int32(i))
+	}
+	wg.Wait()
+	if addr.Load() != 99 {
+		t.Error(addr.Load())
+	}
+}
+
+func TestStoreMinInt32(t *testing.T) {
+	addr := NewInt32(5)
+	if new, changed := addr.StoreMin(7); changed || new != 5 {
+		t.Error(new, changed)
+	}
+	if new, changed := addr.StoreMin(5); changed || new != 5 {
+		t.Error(new, changed)
+	}
+	if new, changed := addr.StoreMin(3); !changed || new != 3 || addr.Load() != 3 {
+		t.Error(new, changed)
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i int32) {
+			defer wg.Done()
+			addr.StoreMin(i)
+		}(int32(i))
+	}
+	wg.Wait()
+	if addr.Load() != 0 {
+		t.Error(addr.Load())
+	}
+}
+
 func BenchmarkSwapInt32(b *testing.B) {
 	addr := NewInt32(1)
 	for i := 0; i < b.N; i++ {
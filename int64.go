@@ -4,6 +4,7 @@
 package atomic
 
 import (
+	"math"
 	"sync/atomic"
 )
 
@@ -34,6 +35,62 @@ func (addr *Int64) Add(delta int64) (new int64) {
 	return atomic.AddInt64(&addr.v, delta)
 }
 
+// FetchAdd atomically adds delta to *addr and returns the previous value.
+func (addr *Int64) FetchAdd(delta int64) (old int64) {
+	return FetchAddInt64(&addr.v, delta)
+}
+
+// FetchSub atomically subtracts delta from *addr and returns the previous value.
+func (addr *Int64) FetchSub(delta int64) (old int64) {
+	return FetchSubInt64(&addr.v, delta)
+}
+
+// FetchAnd atomically replaces *addr with the bitwise AND of *addr and mask
+// and returns the previous value.
+func (addr *Int64) FetchAnd(mask int64) (old int64) {
+	return FetchAndInt64(&addr.v, mask)
+}
+
+// FetchOr atomically replaces *addr with the bitwise OR of *addr and mask
+// and returns the previous value.
+func (addr *Int64) FetchOr(mask int64) (old int64) {
+	return FetchOrInt64(&addr.v, mask)
+}
+
+// CompareAndExchange executes the compare-and-swap operation for an int64 value
+// and returns the value of *addr it was compared with, which is old if the swap happened.
+func (addr *Int64) CompareAndExchange(old, new int64) (val int64) {
+	return CompareAndExchangeInt64(&addr.v, old, new)
+}
+
+// StoreMax atomically stores val into *addr if val is greater than *addr,
+// and returns the resulting value and whether it changed.
+func (addr *Int64) StoreMax(val int64) (new int64, changed bool) {
+	for {
+		old := addr.Load()
+		if val <= old {
+			return old, false
+		}
+		if addr.CompareAndSwap(old, val) {
+			return val, true
+		}
+	}
+}
+
+// StoreMin atomically stores val into *addr if val is less than *addr,
+// and returns the resulting value and whether it changed.
+func (addr *Int64) StoreMin(val int64) (new int64, changed bool) {
+	for {
+		old := addr.Load()
+		if val >= old {
+			return old, false
+		}
+		if addr.CompareAndSwap(old, val) {
+			return val, true
+		}
+	}
+}
+
 // Load atomically loads *addr.
 func (addr *Int64) Load() (val int64) {
 	return atomic.LoadInt64(&addr.v)
@@ -43,3 +100,73 @@ func (addr *Int64) Load() (val int64) {
 func (addr *Int64) Store(val int64) {
 	atomic.StoreInt64(&addr.v, val)
 }
+
+// AddSaturating atomically adds delta to *addr, clamping the result to the
+// range of int64, and returns the new value.
+func (addr *Int64) AddSaturating(delta int64) (new int64) {
+	for {
+		old := addr.Load()
+		val, _ := addInt64(old, delta, math.MinInt64, math.MaxInt64)
+		new = int64(val)
+		if new == old || addr.CompareAndSwap(old, new) {
+			return
+		}
+	}
+}
+
+// AddChecked atomically adds delta to *addr and returns the new value.
+// If the result overflows int64, *addr is left unchanged and ok is false.
+func (addr *Int64) AddChecked(delta int64) (new int64, ok bool) {
+	return addr.AddBounded(delta, math.MinInt64, math.MaxInt64)
+}
+
+// AddBounded atomically adds delta to *addr and returns the new value.
+// If the result falls outside [lo, hi], *addr is left unchanged and ok is false.
+func (addr *Int64) AddBounded(delta, lo, hi int64) (new int64, ok bool) {
+	for {
+		old := addr.Load()
+		val, ok := addInt64(old, delta, lo, hi)
+		if !ok {
+			return old, false
+		}
+		new = int64(val)
+		if addr.CompareAndSwap(old, new) {
+			return new, true
+		}
+	}
+}
+
+// SubSaturating atomically subtracts delta from *addr, clamping the result to the
+// range of int64, and returns the new value.
+func (addr *Int64) SubSaturating(delta int64) (new int64) {
+	for {
+		old := addr.Load()
+		val, _ := subInt64(old, delta, math.MinInt64, math.MaxInt64)
+		new = int64(val)
+		if new == old || addr.CompareAndSwap(old, new) {
+			return
+		}
+	}
+}
+
+// SubChecked atomically subtracts delta from *addr and returns the new value.
+// If the result overflows int64, *addr is left unchanged and ok is false.
+func (addr *Int64) SubChecked(delta int64) (new int64, ok bool) {
+	return addr.SubBounded(delta, math.MinInt64, math.MaxInt64)
+}
+
+// SubBounded atomically subtracts delta from *addr and returns the new value.
+// If the result falls outside [lo, hi], *addr is left unchanged and ok is false.
+func (addr *Int64) SubBounded(delta, lo, hi int64) (new int64, ok bool) {
+	for {
+		old := addr.Load()
+		val, ok := subInt64(old, delta, lo, hi)
+		if !ok {
+			return old, false
+		}
+		new = int64(val)
+		if addr.CompareAndSwap(old, new) {
+			return new, true
+		}
+	}
+}
@@ -4,6 +4,7 @@
 package atomic
 
 import (
+	"math"
 	"sync/atomic"
 )
 
@@ -41,6 +42,89 @@ func (addr *Int8) Add(delta int8) (new int8) {
 	}
 }
 
+// FetchAdd atomically adds delta to *addr and returns the previous value.
+func (addr *Int8) FetchAdd(delta int8) (old int8) {
+	for {
+		old = addr.Load()
+		if addr.CompareAndSwap(old, old+delta) {
+			return
+		}
+	}
+}
+
+// FetchSub atomically subtracts delta from *addr and returns the previous value.
+func (addr *Int8) FetchSub(delta int8) (old int8) {
+	for {
+		old = addr.Load()
+		if addr.CompareAndSwap(old, old-delta) {
+			return
+		}
+	}
+}
+
+// FetchAnd atomically replaces *addr with the bitwise AND of *addr and mask
+// and returns the previous value.
+func (addr *Int8) FetchAnd(mask int8) (old int8) {
+	for {
+		old = addr.Load()
+		if addr.CompareAndSwap(old, old&mask) {
+			return
+		}
+	}
+}
+
+// FetchOr atomically replaces *addr with the bitwise OR of *addr and mask
+// and returns the previous value.
+func (addr *Int8) FetchOr(mask int8) (old int8) {
+	for {
+		old = addr.Load()
+		if addr.CompareAndSwap(old, old|mask) {
+			return
+		}
+	}
+}
+
+// CompareAndExchange executes the compare-and-swap operation for an int8 value
+// and returns the value of *addr it was compared with, which is old if the swap happened.
+func (addr *Int8) CompareAndExchange(old, new int8) (val int8) {
+	for {
+		if addr.CompareAndSwap(old, new) {
+			return old
+		}
+		if val = addr.Load(); val != old {
+			return
+		}
+	}
+}
+
+// StoreMax atomically stores val into *addr if val is greater than *addr,
+// and returns the resulting value and whether it changed.
+func (addr *Int8) StoreMax(val int8) (new int8, changed bool) {
+	for {
+		old := addr.Load()
+		if val <= old {
+			return old, false
+		}
+		if addr.CompareAndSwap(old, val) {
+			return val, true
+		}
+	}
+}
+
+// StoreMin atomically stores val into *addr if val is less than *addr,
+// and returns the resulting value and whether it changed.
+func (addr *Int8) StoreMin(val int8) (new int8, changed bool) {
+	for {
+		old := addr.Load()
+		if val >= old {
+			return old, false
+		}
+		if addr.CompareAndSwap(old, val) {
+			return val, true
+		}
+	}
+}
+
 // Load atomically loads *addr.
 func (addr *Int8) Load() (val int8) {
 	var v = atomic.LoadUint32(&addr.v)
@@ -51,3 +135,73 @@ func (addr *Int8) Load() (val int8) {
 func (addr *Int8) Store(val int8) {
 	atomic.StoreUint32(&addr.v, uint32(val))
 }
+
+// AddSaturating atomically adds delta to *addr, clamping the result to the
+// range of int8, and returns the new value.
+func (addr *Int8) AddSaturating(delta int8) (new int8) {
+	for {
+		old := addr.Load()
+		val, _ := addInt64(int64(old), int64(delta), math.MinInt8, math.MaxInt8)
+		new = int8(val)
+		if new == old || addr.CompareAndSwap(old, new) {
+			return
+		}
+	}
+}
+
+// AddChecked atomically adds delta to *addr and returns the new value.
+// If the result overflows int8, *addr is left unchanged and ok is false.
+func (addr *Int8) AddChecked(delta int8) (new int8, ok bool) {
+	return addr.AddBounded(delta, math.MinInt8, math.MaxInt8)
+}
+
+// AddBounded atomically adds delta to *addr and returns the new value.
+// If the result falls outside [lo, hi], *addr is left unchanged and ok is false.
+func (addr *Int8) AddBounded(delta, lo, hi int8) (new int8, ok bool) {
+	for {
+		old := addr.Load()
+		val, ok := addInt64(int64(old), int64(delta), int64(lo), int64(hi))
+		if !ok {
+			return old, false
+		}
+		new = int8(val)
+		if addr.CompareAndSwap(old, new) {
+			return new, true
+		}
+	}
+}
+
+// SubSaturating atomically subtracts delta from *addr, clamping the result to the
+// range of int8, and returns the new value.
+func (addr *Int8) SubSaturating(delta int8) (new int8) {
+	for {
+		old := addr.Load()
+		val, _ := subInt64(int64(old), int64(delta), math.MinInt8, math.MaxInt8)
+		new = int8(val)
+		if new == old || addr.CompareAndSwap(old, new) {
+			return
+		}
+	}
+}
+
+// SubChecked atomically subtracts delta from *addr and returns the new value.
+// If the result overflows int8, *addr is left unchanged and ok is false.
+func (addr *Int8) SubChecked(delta int8) (new int8, ok bool) {
+	return addr.SubBounded(delta, math.MinInt8, math.MaxInt8)
+}
+
+// SubBounded atomically subtracts delta from *addr and returns the new value.
+// If the result falls outside [lo, hi], *addr is left unchanged and ok is false.
+func (addr *Int8) SubBounded(delta, lo, hi int8) (new int8, ok bool) {
+	for {
+		old := addr.Load()
+		val, ok := subInt64(int64(old), int64(delta), int64(lo), int64(hi))
+		if !ok {
+			return old, false
+		}
+		new = int8(val)
+		if addr.CompareAndSwap(old, new) {
+			return new, true
+		}
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2020 Meng Huang (mhboy@outlook.com)
+// This package is licensed under a MIT license that can be found in the LICENSE file.
+
+package atomic
+
+import (
+	"sync"
+	"time"
+)
+
+// InitFunc is an initialization function.
+type InitFunc func() (val interface{}, err error)
+
+// BackoffFunc returns how long to wait before retrying after the given number of failed attempts.
+type BackoffFunc func(attempts int) time.Duration
+
+// lazyValue boxes the initialized value, which may be nil.
+type lazyValue struct {
+	val interface{}
+}
+
+// Lazy is a value initialized on first use.
+//
+// Unlike sync.Once, a failed initialization is not remembered as done:
+// the next Get runs InitFunc again, after the delay returned by
+// BackoffFunc if it is set. Once InitFunc succeeds, Get returns its
+// value with a single atomic load.
+type Lazy struct {
+	v           Value
+	done        Uint32
+	mu          sync.Mutex
+	attempts    int
+	err         error
+	retry       time.Time
+	InitFunc    InitFunc
+	BackoffFunc BackoffFunc
+	// Clock is used to time the backoff. If nil, the system clock is used.
+	Clock Clock
+}
+
+// NewLazy returns a new Lazy.
+func NewLazy(initFunc InitFunc, backoffFunc BackoffFunc) *Lazy {
+	return &Lazy{InitFunc: initFunc, BackoffFunc: backoffFunc}
+}
+
+// OnceValue returns a function that calls f until it succeeds and then
+// returns the value of that call. Errors are returned to the caller that
+// observed them and do not prevent later calls from retrying.
+func OnceValue(f InitFunc) InitFunc {
+	return NewLazy(f, nil).Get
+}
+
+// Get returns the initialized value, running InitFunc if the value is not
+// initialized yet. While a previous attempt is backing off, Get returns the
+// error of that attempt without running InitFunc.
+func (l *Lazy) Get() (val interface{}, err error) {
+	if l.done.Load() == 1 {
+		return l.v.Load().(*lazyValue).val, nil
+	}
+	return l.get()
+}
+
+func (l *Lazy) get() (val interface{}, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.done.Load() == 1 {
+		return l.v.Load().(*lazyValue).val, nil
+	}
+	if l.InitFunc == nil {
+		panic("InitFunc is nil")
+	}
+	clock := clockOrSystem(l.Clock)
+	if l.err != nil && clock.Now().Before(l.retry) {
+		return nil, l.err
+	}
+	val, err = l.InitFunc()
+	if err != nil {
+		l.attempts++
+		l.err = err
+		if l.BackoffFunc != nil {
+			l.retry = clock.Now().Add(l.BackoffFunc(l.attempts))
+		}
+		return nil, err
+	}
+	l.v.Store(&lazyValue{val: val})
+	l.done.Store(1)
+	l.attempts, l.err = 0, nil
+	return val, nil
+}
+
+// Done reports whether the value has been initialized.
+func (l *Lazy) Done() bool {
+	return l.done.Load() == 1
+}
+
+// Reset forgets the initialized value and any failed attempts,
+// so that the next Get runs InitFunc again.
+func (l *Lazy) Reset() {
+	l.mu.Lock()
+	l.done.Store(0)
+	l.attempts, l.err, l.retry = 0, nil, time.Time{}
+	l.mu.Unlock()
+}
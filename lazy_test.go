@@ -0,0 +1,111 @@
+// Copyright (c) 2020 Meng Huang (mhboy@outlook.com)
+// This package is licensed under a MIT license that can be found in the LICENSE file.
+
+package atomic
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLazy(t *testing.T) {
+	var calls int
+	errInit := errors.New("init")
+	l := NewLazy(func() (interface{}, error) {
+		calls++
+		if calls < 3 {
+			return nil, errInit
+		}
+		return calls, nil
+	}, nil)
+	for i := 1; i < 3; i++ {
+		if val, err := l.Get(); val != nil || err != errInit || l.Done() {
+			t.Error(i, val, err)
+		}
+	}
+	for i := 0; i < 2; i++ {
+		if val, err := l.Get(); val != 3 || err != nil || !l.Done() {
+			t.Error(val, err)
+		}
+	}
+	l.Reset()
+	if l.Done() {
+		t.Error("should be reset")
+	}
+	if val, err := l.Get(); val != 4 || err != nil {
+		t.Error(val, err)
+	}
+}
+
+func TestLazyBackoff(t *testing.T) {
+	clock := newFakeClock()
+	var calls int
+	errInit := errors.New("init")
+	l := NewLazy(func() (interface{}, error) {
+		calls++
+		if calls < 3 {
+			return nil, errInit
+		}
+		return nil, nil
+	}, func(attempts int) time.Duration {
+		return time.Second * time.Duration(attempts)
+	})
+	l.Clock = clock
+	if _, err := l.Get(); err != errInit || calls != 1 {
+		t.Error(err, calls)
+	}
+	if _, err := l.Get(); err != errInit || calls != 1 {
+		t.Error("should back off", err, calls)
+	}
+	clock.Advance(time.Second)
+	if _, err := l.Get(); err != errInit || calls != 2 {
+		t.Error(err, calls)
+	}
+	clock.Advance(time.Second)
+	if _, err := l.Get(); err != errInit || calls != 2 {
+		t.Error("should back off longer", err, calls)
+	}
+	clock.Advance(time.Second)
+	if val, err := l.Get(); val != nil || err != nil || calls != 3 || !l.Done() {
+		t.Error(val, err, calls)
+	}
+}
+
+func TestLazyPanic(t *testing.T) {
+	defer func() {
+		if err := recover(); err == nil {
+			t.Error("should panic")
+		}
+	}()
+	(&Lazy{}).Get()
+}
+
+func TestOnceValue(t *testing.T) {
+	var calls Int32
+	get := OnceValue(func() (interface{}, error) {
+		return calls.Add(1), nil
+	})
+	var wg sync.WaitGroup
+	for i := 0; i < 8192; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if val, err := get(); val != int32(1) || err != nil {
+				t.Error(val, err)
+			}
+		}()
+	}
+	wg.Wait()
+	if calls.Load() != 1 {
+		t.Error(calls.Load())
+	}
+}
+
+func BenchmarkGetLazy(b *testing.B) {
+	l := NewLazy(func() (interface{}, error) { return 1, nil }, nil)
+	for i := 0; i < b.N; i++ {
+		l.Get()
+	}
+}
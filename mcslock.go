@@ -0,0 +1,91 @@
+// Copyright (c) 2020 Meng Huang (mhboy@outlook.com)
+// This package is licensed under a MIT license that can be found in the LICENSE file.
+
+package atomic
+
+import (
+	"context"
+	"sync"
+	"unsafe"
+)
+
+// mcsNode is a waiter in the queue of an MCSLock.
+type mcsNode struct {
+	next   Pointer
+	locked Bool
+}
+
+var mcsNodePool = sync.Pool{New: func() interface{} { return &mcsNode{} }}
+
+// MCSLock is a fair queue spin lock. Each waiter spins on a flag of its own
+// queue node rather than on the shared lock word, which keeps cache traffic
+// low under contention.
+// Only the next waiter in line can take the lock, so it performs poorly
+// when GOMAXPROCS exceeds the number of available CPUs.
+// The zero value for an MCSLock is an unlocked lock.
+type MCSLock struct {
+	tail  Pointer
+	owner *mcsNode
+}
+
+// Lock locks l, queueing behind the current waiters.
+func (l *MCSLock) Lock() {
+	node := l.node()
+	node.locked.Store(true)
+	prev := l.tail.Swap(unsafe.Pointer(node))
+	if prev != nil {
+		(*mcsNode)(prev).next.Store(unsafe.Pointer(node))
+		var b backoff
+		for node.locked.Load() {
+			b.wait()
+		}
+	}
+	l.owner = node
+}
+
+// TryLock tries to lock l and reports whether it succeeded.
+func (l *MCSLock) TryLock() bool {
+	node := l.node()
+	if l.tail.CompareAndSwap(nil, unsafe.Pointer(node)) {
+		l.owner = node
+		return true
+	}
+	mcsNodePool.Put(node)
+	return false
+}
+
+// LockContext locks l, spinning until it is available or ctx is done.
+// A queued waiter cannot leave the queue, so LockContext retries TryLock
+// and does not queue fairly with Lock.
+func (l *MCSLock) LockContext(ctx context.Context) error {
+	return lockContext(ctx, l.TryLock)
+}
+
+// Unlock unlocks l and hands it to the next waiter. It panics if l is not locked.
+func (l *MCSLock) Unlock() {
+	node := l.owner
+	if node == nil {
+		panic("github.com/hslam/atomic: unlock of unlocked MCSLock")
+	}
+	l.owner = nil
+	if node.next.Load() == nil {
+		if l.tail.CompareAndSwap(unsafe.Pointer(node), nil) {
+			mcsNodePool.Put(node)
+			return
+		}
+		// A waiter has swapped the tail but not linked itself yet.
+		var b backoff
+		for node.next.Load() == nil {
+			b.wait()
+		}
+	}
+	(*mcsNode)(node.next.Load()).locked.Store(false)
+	mcsNodePool.Put(node)
+}
+
+func (l *MCSLock) node() *mcsNode {
+	node := mcsNodePool.Get().(*mcsNode)
+	node.next.Store(nil)
+	node.locked.Store(false)
+	return node
+}
@@ -0,0 +1,16 @@
+// Copyright (c) 2020 Meng Huang (mhboy@outlook.com)
+// This package is licensed under a MIT license that can be found in the LICENSE file.
+
+package atomic
+
+import (
+	"testing"
+)
+
+func TestMCSLock(t *testing.T) {
+	testLocker(t, &MCSLock{})
+}
+
+func BenchmarkMCSLock(b *testing.B) {
+	benchmarkLocker(b, &MCSLock{})
+}
@@ -30,6 +30,12 @@ func (addr *Pointer) CompareAndSwap(old, new unsafe.Pointer) (swapped bool) {
 	return atomic.CompareAndSwapPointer(&addr.v, old, new)
 }
 
+// CompareAndExchange executes the compare-and-swap operation for an unsafe.Pointer value
+// and returns the value of *addr it was compared with, which is old if the swap happened.
+func (addr *Pointer) CompareAndExchange(old, new unsafe.Pointer) (val unsafe.Pointer) {
+	return CompareAndExchangePointer(&addr.v, old, new)
+}
+
 // Load atomically loads *addr.
 func (addr *Pointer) Load() (val unsafe.Pointer) {
 	return atomic.LoadPointer(&addr.v)
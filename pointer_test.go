@@ -34,6 +34,17 @@ func TestPointer(t *testing.T) {
 	}
 }
 
+func TestCompareAndExchangePointer(t *testing.T) {
+	var a, b = 1, 2
+	addr := NewPointer(nil)
+	if addr.CompareAndExchange(nil, unsafe.Pointer(&a)) != nil || addr.Load() != unsafe.Pointer(&a) {
+		t.Error(addr.Load())
+	}
+	if addr.CompareAndExchange(nil, unsafe.Pointer(&b)) != unsafe.Pointer(&a) || addr.Load() != unsafe.Pointer(&a) {
+		t.Error(addr.Load())
+	}
+}
+
 func BenchmarkSwapPointer(b *testing.B) {
 	var v string
 	var vp = unsafe.Pointer(&v)
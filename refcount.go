@@ -0,0 +1,113 @@
+// Copyright (c) 2020 Meng Huang (mhboy@outlook.com)
+// This package is licensed under a MIT license that can be found in the LICENSE file.
+
+package atomic
+
+import (
+	"context"
+)
+
+const (
+	refCountClosed = 1 << 30
+	refCountMask   = refCountClosed - 1
+)
+
+// RefCount is a reference counter for a shared resource.
+//
+// Once the count has dropped to zero or the counter has been closed,
+// TryAcquire refuses new references, so a resource published in a Pointer
+// or Value can be retired safely after WaitZero returns.
+type RefCount struct {
+	v Int32
+}
+
+// NewRefCount returns a new RefCount holding count references.
+func NewRefCount(count int32) *RefCount {
+	addr := &RefCount{}
+	addr.v.Store(count & refCountMask)
+	return addr
+}
+
+// Acquire adds a reference on behalf of a caller that already holds one.
+// It panics if the count is zero.
+func (addr *RefCount) Acquire() {
+	if addr.v.Add(1)&refCountMask == 1 {
+		panic("github.com/hslam/atomic: Acquire of a released RefCount")
+	}
+}
+
+// TryAcquire adds a reference if the count is positive and the counter is
+// not closed, and reports whether it did.
+func (addr *RefCount) TryAcquire() (acquired bool) {
+	for {
+		old := addr.v.Load()
+		if old&refCountClosed != 0 || old&refCountMask == 0 {
+			return false
+		}
+		if addr.v.CompareAndSwap(old, old+1) {
+			return true
+		}
+	}
+}
+
+// Release drops a reference and reports whether it was the last one.
+// It panics if the count is already zero.
+func (addr *RefCount) Release() (last bool) {
+	new := addr.v.Add(-1)
+	if new&refCountMask == refCountMask {
+		panic("github.com/hslam/atomic: Release of a released RefCount")
+	}
+	if new&refCountMask == 0 {
+		addr.v.WakeAll()
+		return true
+	}
+	return false
+}
+
+// Close marks the counter as draining, so that TryAcquire fails from now on.
+// It reports whether the call closed the counter.
+func (addr *RefCount) Close() (closed bool) {
+	for {
+		old := addr.v.Load()
+		if old&refCountClosed != 0 {
+			return false
+		}
+		if addr.v.CompareAndSwap(old, old|refCountClosed) {
+			return true
+		}
+	}
+}
+
+// Closed reports whether the counter has been closed.
+func (addr *RefCount) Closed() bool {
+	return addr.v.Load()&refCountClosed != 0
+}
+
+// Count returns the number of references.
+func (addr *RefCount) Count() int32 {
+	return addr.v.Load() & refCountMask
+}
+
+// WaitZero blocks until the count drops to zero.
+func (addr *RefCount) WaitZero() {
+	for {
+		old := addr.v.Load()
+		if old&refCountMask == 0 {
+			return
+		}
+		addr.v.Wait(old)
+	}
+}
+
+// WaitZeroContext is like WaitZero but returns ctx.Err() if ctx is done first.
+func (addr *RefCount) WaitZeroContext(ctx context.Context) error {
+	for {
+		old := addr.v.Load()
+		if old&refCountMask == 0 {
+			return nil
+		}
+		if err := addr.v.WaitContext(ctx, old); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2020 Meng Huang (mhboy@outlook.com)
+// This package is licensed under a MIT license that can be found in the LICENSE file.
+
+package atomic
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRefCount(t *testing.T) {
+	addr := NewRefCount(1)
+	addr.Acquire()
+	if addr.Count() != 2 {
+		t.Error(addr.Count())
+	}
+	if !addr.TryAcquire() {
+		t.Error("should acquire")
+	}
+	if addr.Release() || addr.Release() {
+		t.Error("should not be the last")
+	}
+	if !addr.Close() {
+		t.Error("should close")
+	}
+	if addr.Close() || !addr.Closed() {
+		t.Error("should be closed")
+	}
+	if addr.TryAcquire() {
+		t.Error("should not acquire a closed RefCount")
+	}
+	addr.Acquire()
+	if addr.Count() != 2 {
+		t.Error(addr.Count())
+	}
+	if addr.Release() {
+		t.Error("should not be the last")
+	}
+	if !addr.Release() {
+		t.Error("should be the last")
+	}
+	addr.WaitZero()
+
+	addr = NewRefCount(1)
+	if !addr.Release() {
+		t.Error("should be the last")
+	}
+	if addr.TryAcquire() {
+		t.Error("should not acquire a released RefCount")
+	}
+}
+
+func TestRefCountPanic(t *testing.T) {
+	for _, f := range []func(addr *RefCount){
+		func(addr *RefCount) { addr.Acquire() },
+		func(addr *RefCount) { addr.Release() },
+	} {
+		func() {
+			defer func() {
+				if err := recover(); err == nil {
+					t.Error("should panic")
+				}
+			}()
+			f(NewRefCount(0))
+		}()
+	}
+}
+
+func TestRefCountWaitZero(t *testing.T) {
+	addr := NewRefCount(1)
+	var wg sync.WaitGroup
+	for i := 0; i < 64; i++ {
+		if !addr.TryAcquire() {
+			t.Fatal("should acquire")
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			time.Sleep(time.Millisecond)
+			addr.Release()
+		}()
+	}
+	addr.Close()
+	addr.Release()
+	addr.WaitZero()
+	if addr.Count() != 0 {
+		t.Error(addr.Count())
+	}
+	wg.Wait()
+}
+
+func TestRefCountWaitZeroContext(t *testing.T) {
+	addr := NewRefCount(1)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*10)
+	defer cancel()
+	if err := addr.WaitZeroContext(ctx); err != context.DeadlineExceeded {
+		t.Error(err)
+	}
+	go addr.Release()
+	if err := addr.WaitZeroContext(context.Background()); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestTryAcquireRefCount(t *testing.T) {
+	addr := NewRefCount(1)
+	var wg sync.WaitGroup
+	for i := 0; i < 8192; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if addr.TryAcquire() {
+				addr.Release()
+			}
+		}()
+	}
+	wg.Wait()
+	if addr.Count() != 1 {
+		t.Error(addr.Count())
+	}
+}
+
+func BenchmarkTryAcquireRefCount(b *testing.B) {
+	addr := NewRefCount(1)
+	for i := 0; i < b.N; i++ {
+		addr.TryAcquire()
+		addr.Release()
+	}
+}
@@ -0,0 +1,316 @@
+// Copyright (c) 2020 Meng Huang (mhboy@outlook.com)
+// This package is licensed under a MIT license that can be found in the LICENSE file.
+
+package atomic
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var (
+	// ErrInvalidMetric is returned when a metric name, label or type is not valid.
+	ErrInvalidMetric = errors.New("github.com/hslam/atomic: invalid metric")
+	// ErrDuplicateMetric is returned when a metric with the same name and labels is already registered.
+	ErrDuplicateMetric = errors.New("github.com/hslam/atomic: duplicate metric")
+)
+
+const (
+	prometheusContentType  = "text/plain; version=0.0.4; charset=utf-8"
+	openMetricsContentType = "application/openmetrics-text; version=1.0.0; charset=utf-8"
+)
+
+// Registry is a set of named metrics that can be exposed in the Prometheus
+// text format or in OpenMetrics.
+//
+// An *Int64, *Uint64 or *Float64 is exposed as a gauge, a *Counter as a
+// counter and a *Histogram as a histogram. The metrics are read with atomic
+// loads while they are written, so registering them adds no cost to the
+// code that updates them. A histogram always exposes the same buckets,
+// including the empty ones, so that its series are stable between scrapes.
+type Registry struct {
+	mu       sync.RWMutex
+	families map[string]*metricFamily
+}
+
+type metricFamily struct {
+	name    string
+	help    string
+	typ     string
+	metrics map[string]interface{}
+}
+
+// registryHistogram is a registered Histogram with the indexes of the
+// buckets whose upper bounds are exposed, in increasing order.
+type registryHistogram struct {
+	h       *Histogram
+	buckets []int
+}
+
+// NewRegistry returns a new Registry.
+func NewRegistry() *Registry {
+	return &Registry{families: make(map[string]*metricFamily)}
+}
+
+// Register adds metric to the registry under name and labels.
+// Metrics with the same name must have the same type and help, and
+// different labels. A *Histogram is exposed with the upper bounds 2^k-1
+// for k from 1 to 63; see RegisterHistogram to choose them.
+func (r *Registry) Register(name, help string, labels map[string]string, metric interface{}) error {
+	if h, ok := metric.(*Histogram); ok {
+		bounds := make([]uint64, 63)
+		for k := range bounds {
+			bounds[k] = 1<<uint(k+1) - 1
+		}
+		return r.RegisterHistogram(name, help, labels, h, bounds)
+	}
+	return r.register(name, help, labels, metric)
+}
+
+// RegisterHistogram adds h to the registry under name and labels, exposed
+// with the bucket upper bounds in bounds. Since the buckets of h only
+// approximate the values, each bound is rounded up to the largest value of
+// the bucket of h holding it.
+func (r *Registry) RegisterHistogram(name, help string, labels map[string]string, h *Histogram, bounds []uint64) error {
+	buckets := make([]int, 0, len(bounds))
+	for _, bound := range bounds {
+		buckets = append(buckets, histogramIndex(bound, h.precision))
+	}
+	sort.Ints(buckets)
+	n := 0
+	for i, idx := range buckets {
+		if i == 0 || idx != buckets[n-1] {
+			buckets[n] = idx
+			n++
+		}
+	}
+	return r.register(name, help, labels, &registryHistogram{h: h, buckets: buckets[:n]})
+}
+
+func (r *Registry) register(name, help string, labels map[string]string, metric interface{}) error {
+	if !validMetricName(name) {
+		return fmt.Errorf("%w: name %q", ErrInvalidMetric, name)
+	}
+	var typ string
+	switch metric.(type) {
+	case *Int64, *Uint64, *Float64:
+		typ = "gauge"
+	case *Counter:
+		typ = "counter"
+	case *registryHistogram:
+		typ = "histogram"
+	default:
+		return fmt.Errorf("%w: type %T", ErrInvalidMetric, metric)
+	}
+	for label := range labels {
+		if !validLabelName(label) || typ == "histogram" && label == "le" {
+			return fmt.Errorf("%w: label %q", ErrInvalidMetric, label)
+		}
+	}
+	key := formatLabels(labels)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	f, ok := r.families[name]
+	if !ok {
+		f = &metricFamily{name: name, help: help, typ: typ, metrics: make(map[string]interface{})}
+		r.families[name] = f
+	} else if f.typ != typ || f.help != help {
+		return fmt.Errorf("%w: %s has a different type or help", ErrInvalidMetric, name)
+	}
+	if _, ok := f.metrics[key]; ok {
+		return fmt.Errorf("%w: %s%s", ErrDuplicateMetric, name, key)
+	}
+	f.metrics[key] = metric
+	return nil
+}
+
+// Unregister removes the metric registered under name and labels, and
+// reports whether it was registered.
+func (r *Registry) Unregister(name string, labels map[string]string) bool {
+	key := formatLabels(labels)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	f, ok := r.families[name]
+	if !ok {
+		return false
+	}
+	if _, ok := f.metrics[key]; !ok {
+		return false
+	}
+	delete(f.metrics, key)
+	if len(f.metrics) == 0 {
+		delete(r.families, name)
+	}
+	return true
+}
+
+// WritePrometheus writes the metrics in the Prometheus text format.
+func (r *Registry) WritePrometheus(w io.Writer) error {
+	return r.write(w, false)
+}
+
+// WriteOpenMetrics writes the metrics in the OpenMetrics text format.
+func (r *Registry) WriteOpenMetrics(w io.Writer) error {
+	return r.write(w, true)
+}
+
+// ServeHTTP writes the metrics in OpenMetrics if the request accepts it,
+// or in the Prometheus text format otherwise.
+func (r *Registry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	openMetrics := strings.Contains(req.Header.Get("Accept"), "application/openmetrics-text")
+	if openMetrics {
+		w.Header().Set("Content-Type", openMetricsContentType)
+	} else {
+		w.Header().Set("Content-Type", prometheusContentType)
+	}
+	r.write(w, openMetrics)
+}
+
+func (r *Registry) write(w io.Writer, openMetrics bool) error {
+	var buf bytes.Buffer
+	r.mu.RLock()
+	names := make([]string, 0, len(r.families))
+	for name := range r.families {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		r.families[name].write(&buf, openMetrics)
+	}
+	r.mu.RUnlock()
+	if openMetrics {
+		buf.WriteString("# EOF\n")
+	}
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+func (f *metricFamily) write(buf *bytes.Buffer, openMetrics bool) {
+	name, sample := f.name, f.name
+	if openMetrics && f.typ == "counter" {
+		name = strings.TrimSuffix(f.name, "_total")
+		sample = name + "_total"
+	}
+	if f.help != "" {
+		fmt.Fprintf(buf, "# HELP %s %s\n", name, escapeHelp(f.help, openMetrics))
+	}
+	fmt.Fprintf(buf, "# TYPE %s %s\n", name, f.typ)
+	keys := make([]string, 0, len(f.metrics))
+	for key := range f.metrics {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		switch m := f.metrics[key].(type) {
+		case *Int64:
+			fmt.Fprintf(buf, "%s%s %d\n", sample, key, m.Load())
+		case *Uint64:
+			fmt.Fprintf(buf, "%s%s %d\n", sample, key, m.Load())
+		case *Float64:
+			fmt.Fprintf(buf, "%s%s %s\n", sample, key, formatMetricFloat(m.Load()))
+		case *Counter:
+			fmt.Fprintf(buf, "%s%s %d\n", sample, key, m.Load())
+		case *registryHistogram:
+			writeHistogram(buf, sample, key, m.h.Snapshot(), m.buckets)
+		}
+	}
+}
+
+// writeHistogram writes the cumulative counts of s up to the upper bounds
+// of buckets, whether they are empty or not.
+func writeHistogram(buf *bytes.Buffer, name, key string, s *HistogramSnapshot, buckets []int) {
+	var total uint64
+	i := 0
+	for _, idx := range buckets {
+		for ; i <= idx; i++ {
+			total += s.counts[i]
+		}
+		le := strconv.FormatUint(histogramUpper(idx, s.precision), 10)
+		fmt.Fprintf(buf, "%s_bucket%s %d\n", name, withLabel(key, "le", le), total)
+	}
+	for ; i < len(s.counts); i++ {
+		total += s.counts[i]
+	}
+	fmt.Fprintf(buf, "%s_bucket%s %d\n", name, withLabel(key, "le", "+Inf"), total)
+	fmt.Fprintf(buf, "%s_sum%s %d\n", name, key, s.sum)
+	fmt.Fprintf(buf, "%s_count%s %d\n", name, key, total)
+}
+
+// formatLabels returns labels sorted by name in the exposition format,
+// or an empty string if there are none.
+func formatLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, name := range names {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		fmt.Fprintf(&b, "%s=\"%s\"", name, escapeLabelValue(labels[name]))
+	}
+	b.WriteByte('}')
+	return b.String()
+}
+
+// withLabel appends the label name=value to the formatted labels key.
+func withLabel(key, name, value string) string {
+	label := fmt.Sprintf("%s=\"%s\"", name, value)
+	if key == "" {
+		return "{" + label + "}"
+	}
+	return key[:len(key)-1] + "," + label + "}"
+}
+
+func formatMetricFloat(val float64) string {
+	switch {
+	case math.IsInf(val, 1):
+		return "+Inf"
+	case math.IsInf(val, -1):
+		return "-Inf"
+	case math.IsNaN(val):
+		return "NaN"
+	}
+	return strconv.FormatFloat(val, 'g', -1, 64)
+}
+
+func escapeLabelValue(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+}
+
+func escapeHelp(s string, openMetrics bool) string {
+	if openMetrics {
+		return escapeLabelValue(s)
+	}
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(s)
+}
+
+func validMetricName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, c := range name {
+		if !(c == '_' || c == ':' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || i > 0 && c >= '0' && c <= '9') {
+			return false
+		}
+	}
+	return true
+}
+
+func validLabelName(name string) bool {
+	return validMetricName(name) && !strings.Contains(name, ":") && !strings.HasPrefix(name, "__")
+}
@@ -0,0 +1,213 @@
+// Copyright (c) 2020 Meng Huang (mhboy@outlook.com)
+// This package is licensed under a MIT license that can be found in the LICENSE file.
+
+package atomic
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+func testRegistry(t *testing.T) *Registry {
+	r := NewRegistry()
+	requests := NewCounter(0)
+	requests.Add(42)
+	temperature := NewFloat64(36.6)
+	h := NewHistogram(3)
+	for _, v := range []uint64{1, 2, 2, 9, 100} {
+		h.Record(v)
+	}
+	for _, m := range []struct {
+		name   string
+		help   string
+		labels map[string]string
+		metric interface{}
+	}{
+		{"http_requests_total", "Total HTTP requests.", map[string]string{"method": "GET", "code": "200"}, requests},
+		{"http_requests_total", "Total HTTP requests.", map[string]string{"method": "POST", "code": "500"}, NewCounter(3)},
+		{"temperature_celsius", "Temperature with \"quotes\"\nand a newline.", nil, temperature},
+		{"inf", "", map[string]string{"sign": "+"}, NewFloat64(math.Inf(1))},
+		{"inf", "", map[string]string{"sign": "-"}, NewFloat64(math.Inf(-1))},
+		{"queue_length", "Items waiting.", map[string]string{"path": `C:\tmp "q"`}, NewInt64(-7)},
+		{"goroutines", "Number of goroutines.", nil, NewUint64(12)},
+	} {
+		if err := r.Register(m.name, m.help, m.labels, m.metric); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := r.RegisterHistogram("latency_ns", "Request latency.", map[string]string{"route": "/"}, h, []uint64{1000, 2, 10, 100, 101}); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.RegisterHistogram("latency_ns", "Request latency.", map[string]string{"route": "/empty"}, NewHistogram(3), []uint64{1000, 2, 10, 100, 101}); err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+func testGolden(t *testing.T, name string, got []byte) {
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := ioutil.WriteFile(path, got, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("%s:\n%s\nwant:\n%s", name, got, want)
+	}
+}
+
+func TestRegistryWritePrometheus(t *testing.T) {
+	var buf bytes.Buffer
+	if err := testRegistry(t).WritePrometheus(&buf); err != nil {
+		t.Fatal(err)
+	}
+	testGolden(t, "registry.prom", buf.Bytes())
+}
+
+func TestRegistryWriteOpenMetrics(t *testing.T) {
+	var buf bytes.Buffer
+	if err := testRegistry(t).WriteOpenMetrics(&buf); err != nil {
+		t.Fatal(err)
+	}
+	testGolden(t, "registry.openmetrics", buf.Bytes())
+}
+
+func TestRegistryServeHTTP(t *testing.T) {
+	r := testRegistry(t)
+	for accept, contentType := range map[string]string{
+		"":                                 prometheusContentType,
+		"text/plain":                       prometheusContentType,
+		"application/openmetrics-text;q=1": openMetricsContentType,
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+		req.Header.Set("Accept", accept)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		if w.Header().Get("Content-Type") != contentType {
+			t.Error(accept, w.Header().Get("Content-Type"))
+		}
+		var buf bytes.Buffer
+		if contentType == openMetricsContentType {
+			r.WriteOpenMetrics(&buf)
+		} else {
+			r.WritePrometheus(&buf)
+		}
+		if w.Body.String() != buf.String() {
+			t.Error(accept, w.Body.String())
+		}
+	}
+}
+
+func TestRegistryRegister(t *testing.T) {
+	r := NewRegistry()
+	if err := r.Register("a", "", nil, NewInt64(0)); err != nil {
+		t.Error(err)
+	}
+	if err := r.Register("a", "", nil, NewInt64(0)); !errors.Is(err, ErrDuplicateMetric) {
+		t.Error(err)
+	}
+	if err := r.Register("a", "", nil, NewCounter(0)); !errors.Is(err, ErrInvalidMetric) {
+		t.Error(err)
+	}
+	if err := r.Register("a", "other", map[string]string{"x": "y"}, NewInt64(0)); !errors.Is(err, ErrInvalidMetric) {
+		t.Error(err)
+	}
+	for _, name := range []string{"", "1a", "a-b", "a b"} {
+		if err := r.Register(name, "", nil, NewInt64(0)); !errors.Is(err, ErrInvalidMetric) {
+			t.Error(name, err)
+		}
+	}
+	for _, label := range []string{"", "1a", "a:b", "__a"} {
+		if err := r.Register("b", "", map[string]string{label: ""}, NewInt64(0)); !errors.Is(err, ErrInvalidMetric) {
+			t.Error(label, err)
+		}
+	}
+	if err := r.Register("h", "", map[string]string{"le": "1"}, NewHistogram(3)); !errors.Is(err, ErrInvalidMetric) {
+		t.Error(err)
+	}
+	if err := r.Register("s", "", nil, NewString("")); !errors.Is(err, ErrInvalidMetric) {
+		t.Error(err)
+	}
+	if !r.Unregister("a", nil) || r.Unregister("a", nil) || r.Unregister("b", nil) {
+		t.Error("Unregister")
+	}
+	var buf bytes.Buffer
+	r.WriteOpenMetrics(&buf)
+	if buf.String() != "# EOF\n" {
+		t.Error(buf.String())
+	}
+}
+
+func TestRegistryHistogramBuckets(t *testing.T) {
+	r := NewRegistry()
+	h := NewHistogram(14)
+	if err := r.Register("h", "", nil, h); err != nil {
+		t.Fatal(err)
+	}
+	var empty bytes.Buffer
+	r.WritePrometheus(&empty)
+	if n := bytes.Count(empty.Bytes(), []byte("h_bucket{")); n != 64 {
+		t.Error(n)
+	}
+	h.Record(100)
+	h.Record(1 << 40)
+	var buf bytes.Buffer
+	r.WritePrometheus(&buf)
+	if !bytes.Contains(buf.Bytes(), []byte("h_bucket{le=\"127\"} 1\n")) ||
+		!bytes.Contains(buf.Bytes(), []byte("h_bucket{le=\"2199023255551\"} 2\n")) {
+		t.Error(buf.String())
+	}
+	h.Reset()
+	buf.Reset()
+	r.WritePrometheus(&buf)
+	if buf.String() != empty.String() {
+		t.Error(buf.String())
+	}
+}
+
+func TestRegistryConcurrent(t *testing.T) {
+	r := NewRegistry()
+	c := NewCounter(0)
+	r.Register("c", "", nil, c)
+	var wg sync.WaitGroup
+	for i := 0; i < 8192; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			c.Inc()
+			if i%256 == 0 {
+				r.WritePrometheus(ioutil.Discard)
+			}
+		}(i)
+	}
+	wg.Wait()
+	var buf bytes.Buffer
+	r.WritePrometheus(&buf)
+	if buf.String() != "# TYPE c counter\nc 8192\n" {
+		t.Error(buf.String())
+	}
+}
+
+func BenchmarkRegistryWritePrometheus(b *testing.B) {
+	r := NewRegistry()
+	for _, name := range []string{"a", "b", "c", "d"} {
+		r.Register(name, "help", map[string]string{"k": "v"}, NewInt64(1))
+	}
+	for i := 0; i < b.N; i++ {
+		r.WritePrometheus(ioutil.Discard)
+	}
+}
@@ -0,0 +1,185 @@
+// Copyright (c) 2020 Meng Huang (mhboy@outlook.com)
+// This package is licensed under a MIT license that can be found in the LICENSE file.
+
+package atomic
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sync"
+	"unsafe"
+)
+
+var (
+	// ErrInvalidSegment is returned when a file is not a shared segment.
+	ErrInvalidSegment = errors.New("github.com/hslam/atomic: invalid shared segment")
+	// ErrSegmentFull is returned when all slots of a shared segment are used.
+	ErrSegmentFull = errors.New("github.com/hslam/atomic: shared segment is full")
+	// ErrInvalidSlot is returned when a slot name is too long or the slot has another type.
+	ErrInvalidSlot = errors.New("github.com/hslam/atomic: invalid slot")
+)
+
+// The layout of a shared segment is a 64-byte header followed by 64-byte
+// slots. The header holds the magic, the version, the number of used slots
+// and the number of slots. Each slot holds a NUL-padded name, the kind of
+// the value and the value itself, so every value has its own cache line.
+const (
+	segmentMagic   = "HSATOMIC"
+	segmentVersion = 1
+
+	segmentHeaderSize = 64
+	segmentSlotSize   = 64
+	segmentCountOff   = 12
+	segmentCapOff     = 16
+
+	slotNameSize  = 52
+	slotKindOff   = 52
+	slotValueOff  = 56
+	slotKindInt64 = 1
+	slotKindUint  = 2
+	slotKindBool  = 3
+)
+
+// MaxSlotName is the maximum length in bytes of a slot name.
+const MaxSlotName = slotNameSize
+
+// SharedSegment is a memory mapped file holding named atomic values, so that
+// processes that open the same file can operate on them atomically.
+//
+// Slots are created on first use and are never removed. A file on tmpfs,
+// such as one in /dev/shm, avoids writing the values back to disk.
+// The values returned by a SharedSegment must not be used after Close.
+type SharedSegment struct {
+	buf []byte
+	// mu serializes the changes of the layout within the process, since
+	// locker does not exclude goroutines sharing the same file.
+	mu     sync.Mutex
+	locker segmentLocker
+}
+
+// segmentLocker serializes the changes of the layout between processes.
+type segmentLocker interface {
+	Lock() error
+	Unlock() error
+	Close() error
+}
+
+// SharedSegmentSize returns the size of a shared segment file with slots slots.
+func SharedSegmentSize(slots int) int {
+	return segmentHeaderSize + slots*segmentSlotSize
+}
+
+// Int64 returns the Int64 in the slot name, creating the slot if it does not exist.
+func (s *SharedSegment) Int64(name string) (*Int64, error) {
+	p, err := s.slot(name, slotKindInt64)
+	if err != nil {
+		return nil, err
+	}
+	return (*Int64)(p), nil
+}
+
+// Uint32 returns the Uint32 in the slot name, creating the slot if it does not exist.
+// Wait and Wake of the Uint32 only work within a process.
+func (s *SharedSegment) Uint32(name string) (*Uint32, error) {
+	p, err := s.slot(name, slotKindUint)
+	if err != nil {
+		return nil, err
+	}
+	return (*Uint32)(p), nil
+}
+
+// Bool returns the Bool in the slot name, creating the slot if it does not exist.
+func (s *SharedSegment) Bool(name string) (*Bool, error) {
+	p, err := s.slot(name, slotKindBool)
+	if err != nil {
+		return nil, err
+	}
+	return (*Bool)(p), nil
+}
+
+// Names returns the names of the slots in the order they were created.
+func (s *SharedSegment) Names() []string {
+	count := int(LoadUint32(s.uint32At(segmentCountOff)))
+	names := make([]string, count)
+	for i := range names {
+		names[i] = string(bytes.TrimRight(s.slotAt(i)[:slotNameSize], "\x00"))
+	}
+	return names
+}
+
+func (s *SharedSegment) slot(name string, kind uint32) (unsafe.Pointer, error) {
+	if name == "" || len(name) > slotNameSize || bytes.IndexByte([]byte(name), 0) >= 0 {
+		return nil, fmt.Errorf("%w: name %q", ErrInvalidSlot, name)
+	}
+	if p, err := s.lookup(name, kind); p != nil || err != nil {
+		return p, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.locker.Lock(); err != nil {
+		return nil, err
+	}
+	defer s.locker.Unlock()
+	// Another process may have created the slot before the lock was taken.
+	if p, err := s.lookup(name, kind); p != nil || err != nil {
+		return p, err
+	}
+	count := LoadUint32(s.uint32At(segmentCountOff))
+	if count >= LoadUint32(s.uint32At(segmentCapOff)) {
+		return nil, ErrSegmentFull
+	}
+	slot := s.slotAt(int(count))
+	copy(slot[:slotNameSize], name)
+	StoreUint32((*uint32)(unsafe.Pointer(&slot[slotKindOff])), kind)
+	StoreUint64((*uint64)(unsafe.Pointer(&slot[slotValueOff])), 0)
+	// Publish the slot after it has been written.
+	StoreUint32(s.uint32At(segmentCountOff), count+1)
+	return unsafe.Pointer(&slot[slotValueOff]), nil
+}
+
+// lookup returns the value of the slot name, or nil if it does not exist.
+func (s *SharedSegment) lookup(name string, kind uint32) (unsafe.Pointer, error) {
+	count := int(LoadUint32(s.uint32At(segmentCountOff)))
+	for i := 0; i < count; i++ {
+		slot := s.slotAt(i)
+		if string(bytes.TrimRight(slot[:slotNameSize], "\x00")) != name {
+			continue
+		}
+		if LoadUint32((*uint32)(unsafe.Pointer(&slot[slotKindOff]))) != kind {
+			return nil, fmt.Errorf("%w: %s has another type", ErrInvalidSlot, name)
+		}
+		return unsafe.Pointer(&slot[slotValueOff]), nil
+	}
+	return nil, nil
+}
+
+// init writes the header of a new segment, or checks the header of an
+// existing one. It must be called with the lock held.
+func (s *SharedSegment) init(created bool) error {
+	if created {
+		copy(s.buf, segmentMagic)
+		StoreUint32(s.uint32At(8), segmentVersion)
+		StoreUint32(s.uint32At(segmentCapOff), uint32((len(s.buf)-segmentHeaderSize)/segmentSlotSize))
+		return nil
+	}
+	if len(s.buf) < segmentHeaderSize || string(s.buf[:len(segmentMagic)]) != segmentMagic {
+		return ErrInvalidSegment
+	}
+	if LoadUint32(s.uint32At(8)) != segmentVersion {
+		return fmt.Errorf("%w: version %d", ErrInvalidSegment, LoadUint32(s.uint32At(8)))
+	}
+	if SharedSegmentSize(int(LoadUint32(s.uint32At(segmentCapOff)))) > len(s.buf) {
+		return fmt.Errorf("%w: truncated", ErrInvalidSegment)
+	}
+	return nil
+}
+
+func (s *SharedSegment) uint32At(offset int) *uint32 {
+	return (*uint32)(unsafe.Pointer(&s.buf[offset]))
+}
+
+func (s *SharedSegment) slotAt(i int) []byte {
+	offset := segmentHeaderSize + i*segmentSlotSize
+	return s.buf[offset : offset+segmentSlotSize]
+}
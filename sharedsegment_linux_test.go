@@ -0,0 +1,183 @@
+// Copyright (c) 2020 Meng Huang (mhboy@outlook.com)
+// This package is licensed under a MIT license that can be found in the LICENSE file.
+
+//go:build linux
+// +build linux
+
+package atomic
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestSharedSegment(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "segment")
+	s, err := OpenSharedSegment(path, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	counter, err := s.Int64("counter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	counter.Add(3)
+	flags, err := s.Uint32("flags")
+	if err != nil {
+		t.Fatal(err)
+	}
+	flags.Store(5)
+	ready, err := s.Bool("ready")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ready.Store(true)
+	if names := strings.Join(s.Names(), ","); names != "counter,flags,ready" {
+		t.Error(names)
+	}
+	if _, err := s.Uint32("counter"); !errors.Is(err, ErrInvalidSlot) {
+		t.Error(err)
+	}
+	for _, name := range []string{"", strings.Repeat("x", MaxSlotName+1), "a\x00b"} {
+		if _, err := s.Int64(name); !errors.Is(err, ErrInvalidSlot) {
+			t.Error(name, err)
+		}
+	}
+	if _, err := s.Int64(strings.Repeat("x", MaxSlotName)); err != nil {
+		t.Error(err)
+	}
+	if _, err := s.Int64("full"); !errors.Is(err, ErrSegmentFull) {
+		t.Error(err)
+	}
+	other, err := OpenSharedSegment(path, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer other.Close()
+	if info, _ := os.Stat(path); info.Size() != int64(SharedSegmentSize(4)) {
+		t.Error(info.Size())
+	}
+	if c, err := other.Int64("counter"); err != nil || c.Add(1) != 4 || counter.Load() != 4 {
+		t.Error(err, counter.Load())
+	}
+	if r, err := other.Bool("ready"); err != nil || !r.Load() {
+		t.Error(err)
+	}
+	if f, err := other.Uint32("flags"); err != nil || f.Load() != 5 {
+		t.Error(err)
+	}
+}
+
+func TestSharedSegmentInvalid(t *testing.T) {
+	dir := t.TempDir()
+	for name, data := range map[string]string{
+		"short":   "HSATOMIC",
+		"magic":   strings.Repeat("x", 128),
+		"version": "HSATOMIC\x09\x00\x00\x00" + strings.Repeat("\x00", 116),
+	} {
+		path := filepath.Join(dir, name)
+		ioutil.WriteFile(path, []byte(data), 0644)
+		if _, err := OpenSharedSegment(path, 1); !errors.Is(err, ErrInvalidSegment) {
+			t.Error(name, err)
+		}
+	}
+	if _, err := OpenSharedSegment(filepath.Join(dir, "missing", "segment"), 1); err == nil {
+		t.Error("should fail")
+	}
+}
+
+func TestSharedSegmentConcurrent(t *testing.T) {
+	s, err := OpenSharedSegment(filepath.Join(t.TempDir(), "segment"), 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	var wg sync.WaitGroup
+	for i := 0; i < 8192; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			names := []string{"a", "b", "c", "d"}
+			v, err := s.Int64(names[i%len(names)])
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			v.Add(1)
+		}(i)
+	}
+	wg.Wait()
+	if len(s.Names()) != 4 {
+		t.Error(s.Names())
+	}
+	for _, name := range s.Names() {
+		if v, _ := s.Int64(name); v.Load() != 8192/4 {
+			t.Error(name, v.Load())
+		}
+	}
+}
+
+const sharedSegmentChildEnv = "ATOMIC_SHARED_SEGMENT_CHILD"
+
+// TestSharedSegmentChild is run by TestSharedSegmentProcesses in child processes.
+func TestSharedSegmentChild(t *testing.T) {
+	path := os.Getenv(sharedSegmentChildEnv)
+	if path == "" {
+		t.Skip("run by TestSharedSegmentProcesses")
+	}
+	s, err := OpenSharedSegment(path, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	counter, err := s.Int64("counter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	started, err := s.Uint32("started")
+	if err != nil {
+		t.Fatal(err)
+	}
+	started.Add(1)
+	for i := 0; i < 10000; i++ {
+		counter.Add(1)
+	}
+}
+
+func TestSharedSegmentProcesses(t *testing.T) {
+	if os.Getenv(sharedSegmentChildEnv) != "" {
+		t.Skip("child process")
+	}
+	path := filepath.Join(t.TempDir(), "segment")
+	const processes = 4
+	var wg sync.WaitGroup
+	for i := 0; i < processes; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			cmd := exec.Command(os.Args[0], "-test.run=^TestSharedSegmentChild$")
+			cmd.Env = append(os.Environ(), sharedSegmentChildEnv+"="+path)
+			if out, err := cmd.CombinedOutput(); err != nil {
+				t.Errorf("%v: %s", err, out)
+			}
+		}()
+	}
+	wg.Wait()
+	s, err := OpenSharedSegment(path, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	counter, _ := s.Int64("counter")
+	started, _ := s.Uint32("started")
+	if started.Load() != processes || counter.Load() != processes*10000 {
+		t.Error(started.Load(), counter.Load())
+	}
+}
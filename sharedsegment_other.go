@@ -0,0 +1,22 @@
+// Copyright (c) 2020 Meng Huang (mhboy@outlook.com)
+// This package is licensed under a MIT license that can be found in the LICENSE file.
+
+//go:build !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd
+// +build !darwin,!dragonfly,!freebsd,!linux,!netbsd,!openbsd
+
+package atomic
+
+import (
+	"errors"
+)
+
+// OpenSharedSegment opens the shared segment in the file path.
+// It is not supported on this platform.
+func OpenSharedSegment(path string, slots int) (*SharedSegment, error) {
+	return nil, errors.New("github.com/hslam/atomic: shared segments are not supported on this platform")
+}
+
+// Close closes the segment.
+func (s *SharedSegment) Close() error {
+	return nil
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2020 Meng Huang (mhboy@outlook.com)
+// This package is licensed under a MIT license that can be found in the LICENSE file.
+
+package atomic
+
+import (
+	"runtime"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+// nopLocker is a segmentLocker that excludes nothing, like flock between
+// goroutines sharing the same file. Lock yields to let other goroutines in.
+type nopLocker struct{}
+
+func (nopLocker) Lock() error {
+	runtime.Gosched()
+	return nil
+}
+
+func (nopLocker) Unlock() error { return nil }
+func (nopLocker) Close() error  { return nil }
+
+func TestSharedSegmentCreateConcurrent(t *testing.T) {
+	const slots = 256
+	s := &SharedSegment{buf: make([]byte, SharedSegmentSize(slots)), locker: nopLocker{}}
+	if err := s.init(true); err != nil {
+		t.Fatal(err)
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 8192; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			v, err := s.Int64(strconv.Itoa(i % slots))
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			v.Add(1)
+		}(i)
+	}
+	wg.Wait()
+	if names := s.Names(); len(names) != slots {
+		t.Error(len(names))
+	}
+	for i := 0; i < slots; i++ {
+		if v, _ := s.Int64(strconv.Itoa(i)); v.Load() != 8192/slots {
+			t.Error(i, v.Load())
+		}
+	}
+}
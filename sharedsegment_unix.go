@@ -0,0 +1,98 @@
+// Copyright (c) 2020 Meng Huang (mhboy@outlook.com)
+// This package is licensed under a MIT license that can be found in the LICENSE file.
+
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd
+// +build darwin dragonfly freebsd linux netbsd openbsd
+
+package atomic
+
+import (
+	"os"
+	"syscall"
+)
+
+// OpenSharedSegment opens the shared segment in the file path, creating the
+// file with room for slots slots if it does not exist or is empty.
+// The number of slots of an existing segment is kept.
+func OpenSharedSegment(path string, slots int) (*SharedSegment, error) {
+	if slots <= 0 {
+		panic("github.com/hslam/atomic: non-positive slots")
+	}
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+	locker := &flockLocker{file: file}
+	if err := locker.Lock(); err != nil {
+		file.Close()
+		return nil, err
+	}
+	s, err := openSharedSegment(file, slots)
+	locker.Unlock()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	s.locker = locker
+	return s, nil
+}
+
+func openSharedSegment(file *os.File, slots int) (*SharedSegment, error) {
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	size := int(info.Size())
+	created := size == 0
+	if created {
+		size = SharedSegmentSize(slots)
+		if err := file.Truncate(int64(size)); err != nil {
+			return nil, err
+		}
+	}
+	if size < segmentHeaderSize {
+		return nil, ErrInvalidSegment
+	}
+	buf, err := syscall.Mmap(int(file.Fd()), 0, size, syscall.PROT_READ|syscall.PROT_WRITE, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, err
+	}
+	s := &SharedSegment{buf: buf}
+	if err := s.init(created); err != nil {
+		syscall.Munmap(buf)
+		return nil, err
+	}
+	return s, nil
+}
+
+// Close unmaps the segment and closes the file.
+func (s *SharedSegment) Close() error {
+	err := syscall.Munmap(s.buf)
+	if cerr := s.locker.Close(); err == nil {
+		err = cerr
+	}
+	s.buf = nil
+	return err
+}
+
+// flockLocker locks a file with flock.
+type flockLocker struct {
+	file *os.File
+}
+
+func (l *flockLocker) Lock() error {
+	for {
+		err := syscall.Flock(int(l.file.Fd()), syscall.LOCK_EX)
+		if err != syscall.EINTR {
+			return err
+		}
+	}
+}
+
+func (l *flockLocker) Unlock() error {
+	return syscall.Flock(int(l.file.Fd()), syscall.LOCK_UN)
+}
+
+func (l *flockLocker) Close() error {
+	return l.file.Close()
+}
@@ -0,0 +1,200 @@
+// Copyright (c) 2020 Meng Huang (mhboy@outlook.com)
+// This package is licensed under a MIT license that can be found in the LICENSE file.
+
+package atomic
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+const (
+	windowCountBits = 20
+	windowCountMask = 1<<windowCountBits - 1
+	windowEpochMask = 1<<(64-2*windowCountBits) - 1
+	// MaxSlidingWindowLimit is the largest limit of a SlidingWindow.
+	MaxSlidingWindowLimit = windowCountMask
+)
+
+// SlidingWindow is a lock-free sliding window rate limiter.
+//
+// It counts the events of the current and the previous fixed window and
+// estimates the events of the sliding window ending now by weighting the
+// previous count with the part of it that still overlaps. The window number
+// and both counts are packed into a single Uint64 updated with
+// CompareAndSwap, which bounds the limit by MaxSlidingWindowLimit.
+type SlidingWindow struct {
+	state  Uint64
+	window int64
+	limit  uint64
+	clock  Clock
+}
+
+// NewSlidingWindow returns a new SlidingWindow that allows limit events per window.
+// If clock is nil, the system clock is used.
+func NewSlidingWindow(limit int, window time.Duration, clock Clock) *SlidingWindow {
+	if limit <= 0 || limit > MaxSlidingWindowLimit {
+		panic("github.com/hslam/atomic: limit out of range")
+	}
+	if window <= 0 {
+		panic("github.com/hslam/atomic: non-positive window")
+	}
+	return &SlidingWindow{window: int64(window), limit: uint64(limit), clock: clockOrSystem(clock)}
+}
+
+// Allow is shorthand for AllowN(1).
+func (sw *SlidingWindow) Allow() bool {
+	return sw.AllowN(1)
+}
+
+// AllowN reports whether n events may happen now, counting them if so.
+func (sw *SlidingWindow) AllowN(n int) bool {
+	for {
+		old := sw.state.Load()
+		epoch, prev, cur, elapsed := sw.current(old)
+		count := cur + uint64(n)
+		if float64(prev)*(1-elapsed)+float64(count) > float64(sw.limit) {
+			return false
+		}
+		if sw.state.CompareAndSwap(old, packWindow(epoch, prev, count)) {
+			return true
+		}
+	}
+}
+
+// Reserve is shorthand for ReserveN(1).
+func (sw *SlidingWindow) Reserve() (delay time.Duration, ok bool) {
+	return sw.ReserveN(1)
+}
+
+// ReserveN counts n events, even beyond the limit, and returns how long the
+// caller must wait before the estimated count including them drops to the limit.
+// It returns false if n exceeds the limit.
+func (sw *SlidingWindow) ReserveN(n int) (delay time.Duration, ok bool) {
+	delay, _, ok = sw.reserve(n)
+	return
+}
+
+// reserve is like ReserveN and also returns the window epoch the events were counted in.
+func (sw *SlidingWindow) reserve(n int) (delay time.Duration, epoch uint64, ok bool) {
+	if uint64(n) > sw.limit {
+		return 0, 0, false
+	}
+	for {
+		old := sw.state.Load()
+		epoch, prev, cur, elapsed := sw.current(old)
+		count := cur + uint64(n)
+		if count > windowCountMask {
+			return 0, 0, false
+		}
+		if sw.state.CompareAndSwap(old, packWindow(epoch, prev, count)) {
+			return sw.delay(prev, count, elapsed), epoch, true
+		}
+	}
+}
+
+// Wait is shorthand for WaitN(ctx, 1).
+func (sw *SlidingWindow) Wait(ctx context.Context) error {
+	return sw.WaitN(ctx, 1)
+}
+
+// WaitN blocks until n events may happen. It returns an error if n exceeds
+// the limit, or if ctx is done or its deadline would pass before then, in
+// which case the reserved events are uncounted.
+func (sw *SlidingWindow) WaitN(ctx context.Context, n int) error {
+	delay, epoch, ok := sw.reserve(n)
+	if !ok {
+		return ErrExceedsLimit
+	}
+	return sleep(ctx, sw.clock, delay, func() {
+		sw.cancel(epoch, uint64(n))
+	})
+}
+
+// Count returns the estimated number of events in the sliding window ending now.
+func (sw *SlidingWindow) Count() float64 {
+	_, prev, cur, elapsed := sw.current(sw.state.Load())
+	return float64(prev)*(1-elapsed) + float64(cur)
+}
+
+// delay returns how long it takes until the estimate of the counts drops to the limit,
+// assuming no other events happen.
+func (sw *SlidingWindow) delay(prev, cur uint64, elapsed float64) time.Duration {
+	limit := float64(sw.limit)
+	var at float64
+	switch {
+	case float64(prev)*(1-elapsed)+float64(cur) <= limit:
+		return 0
+	case cur <= sw.limit:
+		// The previous window decays enough before the current one ends.
+		at = 1 - (limit-float64(cur))/float64(prev)
+	default:
+		// The current window becomes the previous one and has to decay.
+		at = 2 - limit/float64(cur)
+	}
+	return time.Duration(math.Ceil((at - elapsed) * float64(sw.window)))
+}
+
+// cancel uncounts n events reserved in the window epoch.
+func (sw *SlidingWindow) cancel(epoch, n uint64) {
+	for {
+		old := sw.state.Load()
+		prev, cur := unpackWindowCounts(old)
+		switch (old>>(2*windowCountBits) - epoch) & windowEpochMask {
+		case 0:
+			if cur < n {
+				return
+			}
+			cur -= n
+		case 1:
+			if prev < n {
+				return
+			}
+			prev -= n
+		default:
+			return
+		}
+		if sw.state.CompareAndSwap(old, packWindow(old>>(2*windowCountBits), prev, cur)) {
+			return
+		}
+	}
+}
+
+// current reads the clock after state was loaded, and returns the current
+// window epoch, the counts of the previous and the current window of state
+// as seen from it, and the elapsed part of it. Since state is loaded first,
+// its epoch is only ahead of the clock if the clock went back. A state one
+// window ahead is then used as the current window from its start, so that
+// an older epoch is never written over it.
+func (sw *SlidingWindow) current(state uint64) (epoch, prev, cur uint64, elapsed float64) {
+	epoch, elapsed = sw.now()
+	stateEpoch := state >> (2 * windowCountBits)
+	prev, cur = unpackWindowCounts(state)
+	switch (epoch - stateEpoch) & windowEpochMask {
+	case 0:
+		return epoch, prev, cur, elapsed
+	case 1:
+		return epoch, cur, 0, elapsed
+	case windowEpochMask:
+		return stateEpoch, prev, cur, 0
+	default:
+		return epoch, 0, 0, elapsed
+	}
+}
+
+// now returns the current window number and the elapsed part of it.
+func (sw *SlidingWindow) now() (epoch uint64, elapsed float64) {
+	now := sw.clock.Now().UnixNano()
+	epoch = uint64(now/sw.window) & windowEpochMask
+	elapsed = float64(now%sw.window) / float64(sw.window)
+	return
+}
+
+func packWindow(epoch, prev, cur uint64) uint64 {
+	return epoch<<(2*windowCountBits) | prev<<windowCountBits | cur
+}
+
+func unpackWindowCounts(state uint64) (prev, cur uint64) {
+	return state >> windowCountBits & windowCountMask, state & windowCountMask
+}
@@ -0,0 +1,184 @@
+// Copyright (c) 2020 Meng Huang (mhboy@outlook.com)
+// This package is licensed under a MIT license that can be found in the LICENSE file.
+
+package atomic
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSlidingWindow(t *testing.T) {
+	clock := newFakeClock()
+	sw := NewSlidingWindow(10, time.Second, clock)
+	if !sw.AllowN(10) {
+		t.Error("should allow the limit")
+	}
+	if sw.Allow() {
+		t.Error("should be full")
+	}
+	clock.Advance(time.Second)
+	if sw.Count() != 10 {
+		t.Error(sw.Count())
+	}
+	if sw.Allow() {
+		t.Error("the previous window should still count in full")
+	}
+	clock.Advance(time.Millisecond * 500)
+	if sw.Count() != 5 {
+		t.Error(sw.Count())
+	}
+	if !sw.AllowN(5) {
+		t.Error("half of the previous window should have slid out")
+	}
+	if sw.Allow() {
+		t.Error("should be full")
+	}
+	clock.Advance(time.Second * 2)
+	if sw.Count() != 0 {
+		t.Error(sw.Count())
+	}
+	if sw.AllowN(11) {
+		t.Error("should not allow more than the limit")
+	}
+}
+
+func TestSlidingWindowReserve(t *testing.T) {
+	clock := newFakeClock()
+	sw := NewSlidingWindow(4, time.Second, clock)
+	if _, ok := sw.ReserveN(5); ok {
+		t.Error("should not reserve more than the limit")
+	}
+	if delay, ok := sw.ReserveN(4); !ok || delay != 0 {
+		t.Error(delay, ok)
+	}
+	// Five events in the current window: wait for the next window and
+	// a quarter of it, until 5*(1-0.25) drops to 4.
+	if delay, ok := sw.Reserve(); !ok || delay != time.Millisecond*1200 {
+		t.Error(delay, ok)
+	}
+	clock.Advance(time.Millisecond * 1500)
+	if sw.Count() != 2.5 {
+		t.Error(sw.Count())
+	}
+	// Five in the previous window and two in the current one:
+	// wait until 5*(1-x) drops to 2.
+	if delay, ok := sw.ReserveN(2); !ok || delay != time.Millisecond*100 {
+		t.Error(delay, ok)
+	}
+	sw.cancel(uint64(clock.Now().UnixNano()/int64(time.Second)), 2)
+	if sw.Count() != 2.5 {
+		t.Error(sw.Count())
+	}
+}
+
+func TestSlidingWindowStale(t *testing.T) {
+	clock := newFakeClock()
+	sw := NewSlidingWindow(10, time.Second, clock)
+	clock.Advance(time.Second)
+	if !sw.AllowN(9) {
+		t.Error("should allow")
+	}
+	// A writer that read the clock one window earlier must neither reset
+	// the newer window nor count its events in an older one.
+	clock.Advance(-time.Second / 2)
+	if !sw.Allow() {
+		t.Error("should allow the last event")
+	}
+	if sw.Allow() {
+		t.Error("should be full")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second/10)
+	defer cancel()
+	if err := sw.WaitN(ctx, 2); err != context.DeadlineExceeded {
+		t.Error(err)
+	}
+	clock.Advance(time.Second / 2)
+	if sw.Count() != 10 {
+		t.Error("should uncount the canceled events from the newer window", sw.Count())
+	}
+	if sw.Allow() {
+		t.Error("should be full")
+	}
+}
+
+func TestSlidingWindowWait(t *testing.T) {
+	clock := newFakeClock()
+	sw := NewSlidingWindow(1, time.Second, clock)
+	if err := sw.Wait(context.Background()); err != nil {
+		t.Error(err)
+	}
+	if err := sw.WaitN(context.Background(), 2); err != ErrExceedsLimit {
+		t.Error(err)
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- sw.Wait(context.Background())
+	}()
+	clock.WaitTimers(1)
+	clock.Advance(time.Second)
+	select {
+	case err := <-done:
+		t.Error("should wait", err)
+	default:
+	}
+	clock.Advance(time.Second / 2)
+	if err := <-done; err != nil {
+		t.Error(err)
+	}
+	// The deadline is earlier than the delay of half a second on the fake clock.
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second/10)
+	defer cancel()
+	if err := sw.Wait(ctx); err != context.DeadlineExceeded {
+		t.Error(err)
+	}
+	if sw.Count() != 1 {
+		t.Error("should uncount the canceled event", sw.Count())
+	}
+	clock.Advance(time.Second / 2)
+	if err := sw.Wait(context.Background()); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSlidingWindowConcurrent(t *testing.T) {
+	clock := newFakeClock()
+	sw := NewSlidingWindow(100, time.Second, clock)
+	var allowed Int64
+	var wg sync.WaitGroup
+	for i := 0; i < 1000; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if sw.Allow() {
+				allowed.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	if allowed.Load() != 100 {
+		t.Error(allowed.Load())
+	}
+}
+
+func TestSlidingWindowPanic(t *testing.T) {
+	for _, args := range [][2]int{{0, 1}, {MaxSlidingWindowLimit + 1, 1}, {1, 0}} {
+		func() {
+			defer func() {
+				if err := recover(); err == nil {
+					t.Error("should panic")
+				}
+			}()
+			NewSlidingWindow(args[0], time.Duration(args[1]), nil)
+		}()
+	}
+}
+
+func BenchmarkAllowSlidingWindow(b *testing.B) {
+	sw := NewSlidingWindow(MaxSlidingWindowLimit, time.Nanosecond, nil)
+	for i := 0; i < b.N; i++ {
+		sw.Allow()
+	}
+}
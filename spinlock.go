@@ -0,0 +1,77 @@
+// Copyright (c) 2020 Meng Huang (mhboy@outlook.com)
+// This package is licensed under a MIT license that can be found in the LICENSE file.
+
+package atomic
+
+import (
+	"context"
+	"runtime"
+)
+
+// maxBackoff is the largest exponent of the busy-wait iterations of a backoff.
+const maxBackoff = 6
+
+// backoff is an exponential busy-wait that yields the processor
+// once the number of iterations has grown large.
+type backoff struct {
+	n uint
+}
+
+func (b *backoff) wait() {
+	if b.n >= maxBackoff {
+		runtime.Gosched()
+		return
+	}
+	for i := 0; i < 1<<b.n; i++ {
+	}
+	b.n++
+}
+
+// lockContext calls tryLock with backoff until it succeeds or ctx is done.
+func lockContext(ctx context.Context, tryLock func() bool) error {
+	var b backoff
+	for !tryLock() {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+		b.wait()
+	}
+	return nil
+}
+
+// SpinLock is a test-and-test-and-set spin lock for very short critical sections.
+// Waiters spin with exponential backoff instead of parking.
+// The zero value for a SpinLock is an unlocked lock.
+type SpinLock struct {
+	v Bool
+}
+
+// Lock locks l, spinning until it is available.
+func (l *SpinLock) Lock() {
+	var b backoff
+	for {
+		if !l.v.Load() && l.v.CompareAndSwap(false, true) {
+			return
+		}
+		b.wait()
+	}
+}
+
+// TryLock tries to lock l and reports whether it succeeded.
+func (l *SpinLock) TryLock() bool {
+	return !l.v.Load() && l.v.CompareAndSwap(false, true)
+}
+
+// LockContext locks l, spinning until it is available or ctx is done.
+func (l *SpinLock) LockContext(ctx context.Context) error {
+	return lockContext(ctx, l.TryLock)
+}
+
+// Unlock unlocks l. It panics if l is not locked.
+func (l *SpinLock) Unlock() {
+	if !l.v.Swap(false) {
+		panic("github.com/hslam/atomic: unlock of unlocked SpinLock")
+	}
+}
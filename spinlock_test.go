@@ -0,0 +1,88 @@
+// Copyright (c) 2020 Meng Huang (mhboy@outlook.com)
+// This package is licensed under a MIT license that can be found in the LICENSE file.
+
+package atomic
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// contextLocker is a lock of this package.
+type contextLocker interface {
+	sync.Locker
+	TryLock() bool
+	LockContext(ctx context.Context) error
+}
+
+func testLocker(t *testing.T, l contextLocker) {
+	var count int
+	var wg sync.WaitGroup
+	for i := 0; i < 64; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 256; j++ {
+				l.Lock()
+				count++
+				l.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if count != 64*256 {
+		t.Error(count)
+	}
+	if !l.TryLock() {
+		t.Error("should lock")
+	}
+	if l.TryLock() {
+		t.Error("should be locked")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*10)
+	defer cancel()
+	if err := l.LockContext(ctx); err != context.DeadlineExceeded {
+		t.Error(err)
+	}
+	go func() {
+		time.Sleep(time.Millisecond)
+		l.Unlock()
+	}()
+	if err := l.LockContext(context.Background()); err != nil {
+		t.Error(err)
+	}
+	l.Unlock()
+	func() {
+		defer func() {
+			if err := recover(); err == nil {
+				t.Error("should panic")
+			}
+		}()
+		l.Unlock()
+	}()
+}
+
+func benchmarkLocker(b *testing.B, l sync.Locker) {
+	var count int
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			l.Lock()
+			count++
+			l.Unlock()
+		}
+	})
+}
+
+func TestSpinLock(t *testing.T) {
+	testLocker(t, &SpinLock{})
+}
+
+func BenchmarkSpinLock(b *testing.B) {
+	benchmarkLocker(b, &SpinLock{})
+}
+
+func BenchmarkMutex(b *testing.B) {
+	benchmarkLocker(b, &sync.Mutex{})
+}
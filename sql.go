@@ -0,0 +1,412 @@
+// Copyright (c) 2020 Meng Huang (mhboy@outlook.com)
+// This package is licensed under a MIT license that can be found in the LICENSE file.
+
+package atomic
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"math/bits"
+	"strconv"
+	"time"
+)
+
+// The Scan and Value methods below make the types implement sql.Scanner and
+// driver.Valuer, so that they can be scanned from and written to a database
+// directly. Scanning NULL stores the zero value.
+
+// Scan implements the sql.Scanner interface.
+func (addr *Int8) Scan(src interface{}) error {
+	val, err := scanInt(src, 8, "int8")
+	if err != nil {
+		return err
+	}
+	addr.Store(int8(val))
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (addr *Int8) Value() (driver.Value, error) {
+	return int64(addr.Load()), nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (addr *Int16) Scan(src interface{}) error {
+	val, err := scanInt(src, 16, "int16")
+	if err != nil {
+		return err
+	}
+	addr.Store(int16(val))
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (addr *Int16) Value() (driver.Value, error) {
+	return int64(addr.Load()), nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (addr *Int32) Scan(src interface{}) error {
+	val, err := scanInt(src, 32, "int32")
+	if err != nil {
+		return err
+	}
+	addr.Store(int32(val))
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (addr *Int32) Value() (driver.Value, error) {
+	return int64(addr.Load()), nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (addr *Int64) Scan(src interface{}) error {
+	val, err := scanInt(src, 64, "int64")
+	if err != nil {
+		return err
+	}
+	addr.Store(val)
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (addr *Int64) Value() (driver.Value, error) {
+	return addr.Load(), nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (addr *Uint8) Scan(src interface{}) error {
+	val, err := scanUint(src, 8, "uint8")
+	if err != nil {
+		return err
+	}
+	addr.Store(uint8(val))
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (addr *Uint8) Value() (driver.Value, error) {
+	return int64(addr.Load()), nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (addr *Uint16) Scan(src interface{}) error {
+	val, err := scanUint(src, 16, "uint16")
+	if err != nil {
+		return err
+	}
+	addr.Store(uint16(val))
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (addr *Uint16) Value() (driver.Value, error) {
+	return int64(addr.Load()), nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (addr *Uint32) Scan(src interface{}) error {
+	val, err := scanUint(src, 32, "uint32")
+	if err != nil {
+		return err
+	}
+	addr.Store(uint32(val))
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (addr *Uint32) Value() (driver.Value, error) {
+	return int64(addr.Load()), nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (addr *Uint64) Scan(src interface{}) error {
+	val, err := scanUint(src, 64, "uint64")
+	if err != nil {
+		return err
+	}
+	addr.Store(val)
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (addr *Uint64) Value() (driver.Value, error) {
+	return uint64Value(addr.Load())
+}
+
+// Scan implements the sql.Scanner interface.
+func (addr *Uintptr) Scan(src interface{}) error {
+	val, err := scanUint(src, bits.UintSize, "uintptr")
+	if err != nil {
+		return err
+	}
+	addr.Store(uintptr(val))
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (addr *Uintptr) Value() (driver.Value, error) {
+	return uint64Value(uint64(addr.Load()))
+}
+
+// Scan implements the sql.Scanner interface.
+func (addr *Float32) Scan(src interface{}) error {
+	val, err := scanFloat(src, 32, "float32")
+	if err != nil {
+		return err
+	}
+	addr.Store(float32(val))
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (addr *Float32) Value() (driver.Value, error) {
+	return float64(addr.Load()), nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (addr *Float64) Scan(src interface{}) error {
+	val, err := scanFloat(src, 64, "float64")
+	if err != nil {
+		return err
+	}
+	addr.Store(val)
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (addr *Float64) Value() (driver.Value, error) {
+	return addr.Load(), nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (addr *Bool) Scan(src interface{}) error {
+	val, err := scanBool(src)
+	if err != nil {
+		return err
+	}
+	addr.Store(val)
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (addr *Bool) Value() (driver.Value, error) {
+	return addr.Load(), nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (addr *Duration) Scan(src interface{}) error {
+	val, err := scanDuration(src)
+	if err != nil {
+		return err
+	}
+	addr.Store(val)
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (addr *Duration) Value() (driver.Value, error) {
+	return int64(addr.Load()), nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (addr *String) Scan(src interface{}) error {
+	val, err := scanString(src)
+	if err != nil {
+		return err
+	}
+	addr.Store(val)
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (addr *String) Value() (driver.Value, error) {
+	return addr.Load(), nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (addr *Bytes) Scan(src interface{}) error {
+	val, err := scanBytes(src)
+	if err != nil {
+		return err
+	}
+	addr.Store(val)
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (addr *Bytes) Value() (driver.Value, error) {
+	if val := addr.Load(); val != nil {
+		return val, nil
+	}
+	return nil, nil
+}
+
+func scanError(src interface{}, typ string, err error) error {
+	if err != nil {
+		return fmt.Errorf("github.com/hslam/atomic: converting %T (%v) to %s: %w", src, src, typ, err)
+	}
+	return fmt.Errorf("github.com/hslam/atomic: converting %T (%v) to %s", src, src, typ)
+}
+
+func scanInt(src interface{}, bitSize int, typ string) (int64, error) {
+	switch s := src.(type) {
+	case nil:
+		return 0, nil
+	case int64:
+		if bitSize < 64 && (s < -1<<(bitSize-1) || s >= 1<<(bitSize-1)) {
+			return 0, scanError(src, typ, strconv.ErrRange)
+		}
+		return s, nil
+	case float64:
+		if limit := math.Exp2(float64(bitSize - 1)); s != math.Trunc(s) || s < -limit || s >= limit {
+			return 0, scanError(src, typ, strconv.ErrRange)
+		}
+		return int64(s), nil
+	case bool:
+		if s {
+			return 1, nil
+		}
+		return 0, nil
+	case []byte:
+		return scanInt(string(s), bitSize, typ)
+	case string:
+		val, err := strconv.ParseInt(s, 10, bitSize)
+		if err != nil {
+			return 0, scanError(src, typ, err)
+		}
+		return val, nil
+	}
+	return 0, scanError(src, typ, nil)
+}
+
+func scanUint(src interface{}, bitSize int, typ string) (uint64, error) {
+	switch s := src.(type) {
+	case nil:
+		return 0, nil
+	case int64:
+		if s < 0 || bitSize < 64 && s >= 1<<bitSize {
+			return 0, scanError(src, typ, strconv.ErrRange)
+		}
+		return uint64(s), nil
+	case float64:
+		if s != math.Trunc(s) || s < 0 || s >= math.Exp2(float64(bitSize)) {
+			return 0, scanError(src, typ, strconv.ErrRange)
+		}
+		return uint64(s), nil
+	case bool:
+		if s {
+			return 1, nil
+		}
+		return 0, nil
+	case []byte:
+		return scanUint(string(s), bitSize, typ)
+	case string:
+		val, err := strconv.ParseUint(s, 10, bitSize)
+		if err != nil {
+			return 0, scanError(src, typ, err)
+		}
+		return val, nil
+	}
+	return 0, scanError(src, typ, nil)
+}
+
+func scanFloat(src interface{}, bitSize int, typ string) (float64, error) {
+	switch s := src.(type) {
+	case nil:
+		return 0, nil
+	case int64:
+		return float64(s), nil
+	case float64:
+		return s, nil
+	case []byte:
+		return scanFloat(string(s), bitSize, typ)
+	case string:
+		val, err := strconv.ParseFloat(s, bitSize)
+		if err != nil {
+			return 0, scanError(src, typ, err)
+		}
+		return val, nil
+	}
+	return 0, scanError(src, typ, nil)
+}
+
+func scanBool(src interface{}) (bool, error) {
+	switch s := src.(type) {
+	case nil:
+		return false, nil
+	case bool:
+		return s, nil
+	case int64:
+		if s == 0 || s == 1 {
+			return s == 1, nil
+		}
+	case []byte:
+		return scanBool(string(s))
+	case string:
+		val, err := strconv.ParseBool(s)
+		if err != nil {
+			return false, scanError(src, "bool", err)
+		}
+		return val, nil
+	}
+	return false, scanError(src, "bool", nil)
+}
+
+func scanDuration(src interface{}) (time.Duration, error) {
+	switch s := src.(type) {
+	case []byte:
+		return scanDuration(string(s))
+	case string:
+		if val, err := time.ParseDuration(s); err == nil {
+			return val, nil
+		}
+	}
+	val, err := scanInt(src, 64, "time.Duration")
+	return time.Duration(val), err
+}
+
+func scanString(src interface{}) (string, error) {
+	switch s := src.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return s, nil
+	case []byte:
+		return string(s), nil
+	case int64:
+		return strconv.FormatInt(s, 10), nil
+	case float64:
+		return strconv.FormatFloat(s, 'g', -1, 64), nil
+	case bool:
+		return strconv.FormatBool(s), nil
+	case time.Time:
+		return s.Format(time.RFC3339Nano), nil
+	}
+	return "", scanError(src, "string", nil)
+}
+
+func scanBytes(src interface{}) ([]byte, error) {
+	switch s := src.(type) {
+	case nil:
+		return nil, nil
+	case []byte:
+		// The driver may reuse src after Scan returns.
+		return append([]byte{}, s...), nil
+	}
+	val, err := scanString(src)
+	if err != nil {
+		return nil, scanError(src, "[]byte", nil)
+	}
+	return []byte(val), nil
+}
+
+// uint64Value returns val as an int64, since driver.Value has no uint64.
+func uint64Value(val uint64) (driver.Value, error) {
+	if val > math.MaxInt64 {
+		return nil, fmt.Errorf("github.com/hslam/atomic: uint64 %d overflows int64", val)
+	}
+	return int64(val), nil
+}
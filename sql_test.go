@@ -0,0 +1,220 @@
+// Copyright (c) 2020 Meng Huang (mhboy@outlook.com)
+// This package is licensed under a MIT license that can be found in the LICENSE file.
+
+package atomic
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"math"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeDriver is a database/sql driver that stores the arguments of every
+// INSERT as a row and returns all rows on SELECT.
+type fakeDriver struct {
+	mu   sync.Mutex
+	rows [][]driver.Value
+}
+
+func (d *fakeDriver) Open(name string) (driver.Conn, error) {
+	return &fakeConn{d: d}, nil
+}
+
+type fakeConn struct {
+	d *fakeDriver
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeStmt{d: c.d, query: query}, nil
+}
+
+func (c *fakeConn) Close() error {
+	return nil
+}
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("transactions are not supported")
+}
+
+type fakeStmt struct {
+	d     *fakeDriver
+	query string
+}
+
+func (s *fakeStmt) Close() error {
+	return nil
+}
+
+func (s *fakeStmt) NumInput() int {
+	return -1
+}
+
+func (s *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.d.mu.Lock()
+	defer s.d.mu.Unlock()
+	if s.query == "DELETE" {
+		s.d.rows = nil
+		return driver.RowsAffected(0), nil
+	}
+	s.d.rows = append(s.d.rows, args)
+	return driver.RowsAffected(1), nil
+}
+
+func (s *fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.d.mu.Lock()
+	defer s.d.mu.Unlock()
+	return &fakeRows{rows: append([][]driver.Value{}, s.d.rows...)}, nil
+}
+
+type fakeRows struct {
+	rows [][]driver.Value
+}
+
+func (r *fakeRows) Columns() []string {
+	if len(r.rows) == 0 {
+		return nil
+	}
+	return make([]string, len(r.rows[0]))
+}
+
+func (r *fakeRows) Close() error {
+	return nil
+}
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
+
+var testDriver = &fakeDriver{}
+
+func init() {
+	sql.Register("atomictest", testDriver)
+}
+
+func TestSQL(t *testing.T) {
+	db, err := sql.Open("atomictest", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	db.Exec("DELETE")
+	in := []interface{}{
+		NewInt8(-8), NewInt16(-16), NewInt32(-32), NewInt64(-64),
+		NewUint8(8), NewUint16(16), NewUint32(32), NewUint64(math.MaxInt64),
+		NewUintptr(1), NewFloat32(0.5), NewFloat64(1.25),
+		NewBool(true), NewDuration(time.Second), NewString("s"), NewBytes([]byte("b")),
+	}
+	if _, err := db.Exec("INSERT", in...); err != nil {
+		t.Fatal(err)
+	}
+	out := []interface{}{
+		&Int8{}, &Int16{}, &Int32{}, &Int64{},
+		&Uint8{}, &Uint16{}, &Uint32{}, &Uint64{},
+		&Uintptr{}, &Float32{}, &Float64{},
+		&Bool{}, &Duration{}, &String{}, &Bytes{},
+	}
+	if err := db.QueryRow("SELECT").Scan(out...); err != nil {
+		t.Fatal(err)
+	}
+	for i := range in {
+		want, _ := in[i].(driver.Valuer).Value()
+		got, _ := out[i].(driver.Valuer).Value()
+		if b, ok := want.([]byte); ok {
+			want, got = string(b), string(got.([]byte))
+		}
+		if got != want {
+			t.Errorf("%T: %v != %v", out[i], got, want)
+		}
+	}
+	if _, err := db.Exec("INSERT", NewUint64(math.MaxUint64)); err == nil {
+		t.Error("should overflow")
+	}
+	db.Exec("DELETE")
+	nulls := make([]interface{}, len(in))
+	if _, err := db.Exec("INSERT", nulls...); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.QueryRow("SELECT").Scan(out...); err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range out {
+		if val, _ := v.(driver.Valuer).Value(); val != nil && val != int64(0) && val != float64(0) && val != false && val != "" {
+			t.Errorf("%T: %v", v, val)
+		}
+	}
+}
+
+func TestSQLScan(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, c := range []struct {
+		dest sql.Scanner
+		src  interface{}
+		want interface{}
+	}{
+		{&Int8{}, int64(127), int8(127)},
+		{&Int16{}, []byte("-300"), int16(-300)},
+		{&Int32{}, float64(7), int32(7)},
+		{&Int64{}, true, int64(1)},
+		{&Uint8{}, "255", uint8(255)},
+		{&Uint16{}, float64(65535), uint16(65535)},
+		{&Uint32{}, int64(math.MaxUint32), uint32(math.MaxUint32)},
+		{&Uint64{}, "18446744073709551615", uint64(math.MaxUint64)},
+		{&Uintptr{}, int64(3), uintptr(3)},
+		{&Float32{}, "0.25", float32(0.25)},
+		{&Float64{}, int64(3), float64(3)},
+		{&Bool{}, int64(1), true},
+		{&Bool{}, []byte("false"), false},
+		{&Duration{}, "1m", time.Minute},
+		{&Duration{}, int64(5), time.Duration(5)},
+		{&String{}, int64(5), "5"},
+		{&String{}, 1.5, "1.5"},
+		{&String{}, now, "2020-01-01T00:00:00Z"},
+		{&Bytes{}, "x", "x"},
+	} {
+		if err := c.dest.Scan(c.src); err != nil {
+			t.Errorf("%T(%v): %v", c.dest, c.src, err)
+			continue
+		}
+		got := reflect.ValueOf(c.dest).MethodByName("Load").Call(nil)[0].Interface()
+		if b, ok := got.([]byte); ok {
+			got = string(b)
+		}
+		if got != c.want {
+			t.Errorf("%T(%v): %v != %v", c.dest, c.src, got, c.want)
+		}
+	}
+	for _, c := range []struct {
+		dest sql.Scanner
+		src  interface{}
+	}{
+		{&Int8{}, int64(128)},
+		{&Int16{}, "x"},
+		{&Int32{}, 1.5},
+		{&Int64{}, now},
+		{&Uint8{}, int64(-1)},
+		{&Uint16{}, float64(65536)},
+		{&Uint32{}, "-1"},
+		{&Uint64{}, now},
+		{&Float32{}, "x"},
+		{&Float64{}, true},
+		{&Bool{}, int64(2)},
+		{&Duration{}, "x"},
+		{&String{}, []int{}},
+		{&Bytes{}, []int{}},
+	} {
+		if err := c.dest.Scan(c.src); err == nil {
+			t.Errorf("%T(%v) should fail", c.dest, c.src)
+		}
+	}
+}
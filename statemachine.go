@@ -0,0 +1,301 @@
+// Copyright (c) 2020 Meng Huang (mhboy@outlook.com)
+// This package is licensed under a MIT license that can be found in the LICENSE file.
+
+package atomic
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"unsafe"
+)
+
+var (
+	// ErrInvalidTransition is returned when a transition has not been declared.
+	ErrInvalidTransition = errors.New("github.com/hslam/atomic: invalid transition")
+	// ErrStateMismatch is returned when the current state is not a source of a transition.
+	ErrStateMismatch = errors.New("github.com/hslam/atomic: state mismatch")
+)
+
+const (
+	stateBits = 16
+	stateMask = 1<<stateBits - 1
+	// MaxStates is the largest number of states declared by NewStates.
+	MaxStates = stateMask + 1
+)
+
+// State is a state of a StateMachine. States are declared by NewStates,
+// and format as their names.
+type State struct {
+	index int32
+	names *[]string
+}
+
+// NewStates declares len(names) states named by names, in order.
+// The states of a StateMachine must be declared by the same call.
+func NewStates(names ...string) []State {
+	if len(names) > MaxStates {
+		panic("github.com/hslam/atomic: too many states")
+	}
+	list := append([]string(nil), names...)
+	states := make([]State, len(names))
+	for i := range states {
+		states[i] = State{index: int32(i), names: &list}
+	}
+	return states
+}
+
+// String returns the name of s.
+func (s State) String() string {
+	if s.names == nil {
+		return "State(undeclared)"
+	}
+	return (*s.names)[s.index]
+}
+
+// Transition is an allowed change from one state to another.
+type Transition struct {
+	From State
+	To   State
+}
+
+// HookFunc is called after each state change.
+type HookFunc func(from, to State)
+
+// StateEvent is a state change delivered by a StateWatcher.
+type StateEvent struct {
+	From State
+	To   State
+	// Seq is the number of state changes up to and including this one.
+	Seq uint64
+}
+
+// StateWatcher receives the state changes of a StateMachine.
+type StateWatcher struct {
+	// C delivers a StateEvent after each state change. Events of concurrent
+	// changes may arrive out of order, and events that do not fit in the
+	// buffer are dropped; Seq orders them and reveals the gaps.
+	// C is closed by Stop.
+	C <-chan StateEvent
+
+	c       chan StateEvent
+	m       *StateMachine
+	mu      sync.Mutex
+	stopped bool
+}
+
+// StateMachine represents a state that only changes along declared
+// transitions. The state and the number of changes are packed into a
+// single Uint64, so that every change has its own sequence number.
+type StateMachine struct {
+	state       Uint64
+	names       *[]string
+	transitions map[Transition]struct{}
+	hooks       Pointer
+	watchers    Pointer
+}
+
+// NewStateMachine returns a new StateMachine in the initial state.
+// The state may only change along transitions, between states declared
+// by the same call to NewStates as initial.
+func NewStateMachine(initial State, transitions []Transition) *StateMachine {
+	m := &StateMachine{names: initial.names, transitions: make(map[Transition]struct{}, len(transitions))}
+	if !m.valid(initial) {
+		panic("github.com/hslam/atomic: undeclared initial state")
+	}
+	for _, t := range transitions {
+		if !m.valid(t.From) || !m.valid(t.To) {
+			panic("github.com/hslam/atomic: transition of an undeclared state")
+		}
+		m.transitions[t] = struct{}{}
+	}
+	m.state.Store(uint64(initial.index))
+	return m
+}
+
+// Load atomically loads the current state.
+func (m *StateMachine) Load() State {
+	return m.unpackState(m.state.Load())
+}
+
+// Is reports whether the current state is s.
+func (m *StateMachine) Is(s State) bool {
+	return m.Load() == s
+}
+
+// Can reports whether the transition from from to to has been declared.
+func (m *StateMachine) Can(from, to State) bool {
+	_, ok := m.transitions[Transition{From: from, To: to}]
+	return ok
+}
+
+// Transition atomically changes the state from from to to.
+// It returns ErrInvalidTransition if the transition has not been declared,
+// or ErrStateMismatch if the current state is not from.
+func (m *StateMachine) Transition(from, to State) error {
+	if !m.Can(from, to) {
+		return m.errorf(ErrInvalidTransition, from, to)
+	}
+	for {
+		old := m.state.Load()
+		if prev := m.unpackState(old); prev != from {
+			return m.errorf(ErrStateMismatch, prev, to)
+		}
+		if m.state.CompareAndSwap(old, nextState(old, to)) {
+			m.changed(from, to, old>>stateBits+1)
+			return nil
+		}
+	}
+}
+
+// TransitionAny atomically changes the state to to if the current state is
+// one of from, and returns the state it changed from.
+// It returns ErrStateMismatch if the current state is not one of from, or
+// ErrInvalidTransition if the transition from the current state has not
+// been declared.
+func (m *StateMachine) TransitionAny(from []State, to State) (prev State, err error) {
+	for {
+		old := m.state.Load()
+		prev = m.unpackState(old)
+		var found bool
+		for _, s := range from {
+			if s == prev {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return prev, m.errorf(ErrStateMismatch, prev, to)
+		}
+		if !m.Can(prev, to) {
+			return prev, m.errorf(ErrInvalidTransition, prev, to)
+		}
+		if m.state.CompareAndSwap(old, nextState(old, to)) {
+			m.changed(prev, to, old>>stateBits+1)
+			return prev, nil
+		}
+	}
+}
+
+// OnTransition registers f to be called after each state change.
+// Hooks run on the goroutine that changed the state, in registration order.
+// Hooks of concurrent changes may be called out of order; use Watch to
+// receive the changes with their sequence numbers.
+func (m *StateMachine) OnTransition(f HookFunc) {
+	for {
+		old := m.hooks.Load()
+		var hooks []HookFunc
+		if old != nil {
+			hooks = append(hooks, *(*[]HookFunc)(old)...)
+		}
+		hooks = append(hooks, f)
+		if m.hooks.CompareAndSwap(old, unsafe.Pointer(&hooks)) {
+			return
+		}
+	}
+}
+
+// Watch returns a new StateWatcher whose channel buffers up to size events.
+func (m *StateMachine) Watch(size int) *StateWatcher {
+	c := make(chan StateEvent, size)
+	w := &StateWatcher{C: c, c: c, m: m}
+	for {
+		old := m.watchers.Load()
+		var list []*StateWatcher
+		if old != nil {
+			list = append(list, *(*[]*StateWatcher)(old)...)
+		}
+		list = append(list, w)
+		if m.watchers.CompareAndSwap(old, unsafe.Pointer(&list)) {
+			return w
+		}
+	}
+}
+
+// Seq returns the number of state changes.
+func (m *StateMachine) Seq() uint64 {
+	return m.state.Load() >> stateBits
+}
+
+// String returns the name of the current state.
+func (m *StateMachine) String() string {
+	return m.Load().String()
+}
+
+// Stop unsubscribes the StateWatcher and closes C.
+// It reports whether the call stopped the StateWatcher.
+func (w *StateWatcher) Stop() bool {
+	m := w.m
+	for {
+		old := m.watchers.Load()
+		if old == nil {
+			break
+		}
+		list := make([]*StateWatcher, 0, len(*(*[]*StateWatcher)(old)))
+		for _, watcher := range *(*[]*StateWatcher)(old) {
+			if watcher != w {
+				list = append(list, watcher)
+			}
+		}
+		var ptr unsafe.Pointer
+		if len(list) > 0 {
+			ptr = unsafe.Pointer(&list)
+		}
+		if m.watchers.CompareAndSwap(old, ptr) {
+			break
+		}
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.stopped {
+		return false
+	}
+	w.stopped = true
+	close(w.c)
+	return true
+}
+
+// send delivers the event if there is room for it in the buffer.
+func (w *StateWatcher) send(event StateEvent) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.stopped {
+		return
+	}
+	select {
+	case w.c <- event:
+	default:
+	}
+}
+
+func (m *StateMachine) changed(from, to State, seq uint64) {
+	if hooks := m.hooks.Load(); hooks != nil {
+		for _, f := range *(*[]HookFunc)(hooks) {
+			f(from, to)
+		}
+	}
+	if watchers := m.watchers.Load(); watchers != nil {
+		event := StateEvent{From: from, To: to, Seq: seq}
+		for _, w := range *(*[]*StateWatcher)(watchers) {
+			w.send(event)
+		}
+	}
+}
+
+// unpackState returns the state packed in v.
+func (m *StateMachine) unpackState(v uint64) State {
+	return State{index: int32(v & stateMask), names: m.names}
+}
+
+// nextState returns the packed state to after the change from old.
+func nextState(old uint64, to State) uint64 {
+	return (old>>stateBits+1)<<stateBits | uint64(to.index)
+}
+
+func (m *StateMachine) valid(s State) bool {
+	return s.names != nil && s.names == m.names
+}
+
+func (m *StateMachine) errorf(err error, from, to State) error {
+	return fmt.Errorf("%w: %s -> %s", err, from, to)
+}
@@ -0,0 +1,191 @@
+// Copyright (c) 2020 Meng Huang (mhboy@outlook.com)
+// This package is licensed under a MIT license that can be found in the LICENSE file.
+
+package atomic
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+var (
+	connStates      = NewStates("idle", "connecting", "connected", "closed")
+	stateIdle       = connStates[0]
+	stateConnecting = connStates[1]
+	stateConnected  = connStates[2]
+	stateClosed     = connStates[3]
+)
+
+func newConnStateMachine() *StateMachine {
+	return NewStateMachine(stateIdle, []Transition{
+		{stateIdle, stateConnecting},
+		{stateConnecting, stateConnected},
+		{stateConnecting, stateIdle},
+		{stateIdle, stateClosed},
+		{stateConnecting, stateClosed},
+		{stateConnected, stateClosed},
+	})
+}
+
+func TestStateMachine(t *testing.T) {
+	m := newConnStateMachine()
+	var changes []Transition
+	m.OnTransition(func(from, to State) {
+		changes = append(changes, Transition{from, to})
+	})
+	if !m.Is(stateIdle) || m.String() != "idle" {
+		t.Error(m)
+	}
+	if err := m.Transition(stateIdle, stateConnected); !errors.Is(err, ErrInvalidTransition) {
+		t.Error(err)
+	}
+	if err := m.Transition(stateConnecting, stateConnected); !errors.Is(err, ErrStateMismatch) {
+		t.Error(err)
+	} else if err.Error() != "github.com/hslam/atomic: state mismatch: idle -> connected" {
+		t.Error(err)
+	}
+	if err := m.Transition(stateIdle, stateConnecting); err != nil {
+		t.Error(err)
+	}
+	if err := m.Transition(stateConnecting, stateConnected); err != nil {
+		t.Error(err)
+	}
+	if m.Load() != stateConnected || m.String() != "connected" {
+		t.Error(m)
+	}
+	if len(changes) != 2 || changes[0] != (Transition{stateIdle, stateConnecting}) || changes[1] != (Transition{stateConnecting, stateConnected}) {
+		t.Error(changes)
+	}
+	if fmt.Sprint(m.Load()) != "connected" || fmt.Sprintf("%v", stateClosed) != "closed" {
+		t.Error(m.Load(), stateClosed)
+	}
+	if (State{}).String() != "State(undeclared)" {
+		t.Error(State{})
+	}
+	if m.Seq() != 2 {
+		t.Error(m.Seq())
+	}
+}
+
+func TestStateMachineWatch(t *testing.T) {
+	m := newConnStateMachine()
+	w := m.Watch(2)
+	m.Transition(stateIdle, stateConnecting)
+	m.Transition(stateConnecting, stateIdle)
+	m.Transition(stateIdle, stateConnecting)
+	for _, want := range []StateEvent{{stateIdle, stateConnecting, 1}, {stateConnecting, stateIdle, 2}} {
+		if event := <-w.C; event != want {
+			t.Error(event)
+		}
+	}
+	select {
+	case event := <-w.C:
+		t.Error("should drop the event that did not fit", event)
+	default:
+	}
+	if !w.Stop() || w.Stop() {
+		t.Error("should stop once")
+	}
+	m.Transition(stateConnecting, stateConnected)
+	if _, ok := <-w.C; ok {
+		t.Error("should be closed")
+	}
+}
+
+func TestStateMachineWatchConcurrent(t *testing.T) {
+	m := newConnStateMachine()
+	w := m.Watch(2 * 8192)
+	defer w.Stop()
+	var wg sync.WaitGroup
+	for i := 0; i < 8192; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if m.Transition(stateIdle, stateConnecting) == nil {
+				m.Transition(stateConnecting, stateIdle)
+			}
+		}()
+	}
+	wg.Wait()
+	events := make(map[uint64]StateEvent)
+	for len(w.C) > 0 {
+		event := <-w.C
+		events[event.Seq] = event
+	}
+	if uint64(len(events)) != m.Seq() {
+		t.Fatal(len(events), m.Seq())
+	}
+	// Ordered by Seq, every change starts where the previous one ended.
+	from := stateIdle
+	for seq := uint64(1); seq <= m.Seq(); seq++ {
+		if events[seq].From != from {
+			t.Fatal(seq, events[seq])
+		}
+		from = events[seq].To
+	}
+}
+
+func TestStateMachineTransitionAny(t *testing.T) {
+	m := newConnStateMachine()
+	if _, err := m.TransitionAny([]State{stateConnecting, stateConnected}, stateClosed); !errors.Is(err, ErrStateMismatch) {
+		t.Error(err)
+	}
+	if _, err := m.TransitionAny([]State{stateIdle}, stateConnected); !errors.Is(err, ErrInvalidTransition) {
+		t.Error(err)
+	}
+	if prev, err := m.TransitionAny([]State{stateIdle, stateConnecting, stateConnected}, stateClosed); err != nil || prev != stateIdle {
+		t.Error(prev, err)
+	}
+	if !m.Is(stateClosed) {
+		t.Error(m)
+	}
+}
+
+func TestStateMachinePanic(t *testing.T) {
+	for _, f := range []func(){
+		func() { NewStateMachine(State{}, nil) },
+		func() { NewStateMachine(stateIdle, []Transition{{stateIdle, NewStates("a")[0]}}) },
+		func() { NewStates(make([]string, MaxStates+1)...) },
+	} {
+		func() {
+			defer func() {
+				if err := recover(); err == nil {
+					t.Error("should panic")
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+func TestTransitionStateMachine(t *testing.T) {
+	m := newConnStateMachine()
+	var transitions Int32
+	m.OnTransition(func(from, to State) {
+		transitions.Add(1)
+	})
+	var wg sync.WaitGroup
+	for i := 0; i < 8192; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if m.Transition(stateIdle, stateConnecting) == nil {
+				m.Transition(stateConnecting, stateIdle)
+			}
+		}()
+	}
+	wg.Wait()
+	if !m.Is(stateIdle) || transitions.Load()%2 != 0 {
+		t.Error(m, transitions.Load())
+	}
+}
+
+func BenchmarkTransitionStateMachine(b *testing.B) {
+	m := newConnStateMachine()
+	for i := 0; i < b.N; i++ {
+		m.Transition(stateIdle, stateConnecting)
+		m.Transition(stateConnecting, stateIdle)
+	}
+}
@@ -20,7 +20,9 @@ func (addr *String) Swap(new string) (old string) {
 	for {
 		load := addr.v.Load()
 		if addr.v.compareAndSwap(load, new) {
-			return load.(string)
+			// load is nil before the first store.
+			old, _ = load.(string)
+			return
 		}
 	}
 }
@@ -37,9 +39,22 @@ func (addr *String) CompareAndSwap(old, new string) (swapped bool) {
 // Add atomically adds delta to *addr and returns the new value.
 func (addr *String) Add(delta string) (new string) {
 	for {
-		old := addr.v.Load()
-		new = old.(string) + delta
-		if addr.v.compareAndSwap(old, new) {
+		load := addr.v.Load()
+		old, _ := load.(string)
+		new = old + delta
+		if addr.v.compareAndSwap(load, new) {
+			return
+		}
+	}
+}
+
+// FetchAdd atomically appends delta to *addr and returns the previous value.
+func (addr *String) FetchAdd(delta string) (old string) {
+	for {
+		load := addr.v.Load()
+		// load is nil before the first store.
+		old, _ = load.(string)
+		if addr.v.compareAndSwap(load, old+delta) {
 			return
 		}
 	}
@@ -58,3 +73,14 @@ func (addr *String) Load() (val string) {
 func (addr *String) Store(val string) {
 	addr.v.Store(val)
 }
+
+// Watch returns a new Watcher that is notified after each successful write to addr.
+// The Value of each Event holds an string.
+func (addr *String) Watch() *Watcher {
+	return addr.v.Watch()
+}
+
+// Generation returns the number of successful writes to addr.
+func (addr *String) Generation() uint64 {
+	return addr.v.Generation()
+}
@@ -83,6 +83,24 @@ func TestSwapString(t *testing.T) {
 	wg.Wait()
 }
 
+func TestFetchAddString(t *testing.T) {
+	addr := NewString("a")
+	if addr.FetchAdd("b") != "a" || addr.Load() != "ab" {
+		t.Error(addr.Load())
+	}
+}
+
+func TestFetchAddZeroString(t *testing.T) {
+	var addr String
+	if addr.FetchAdd("a") != "" || addr.Load() != "a" {
+		t.Error(addr.Load())
+	}
+	var zero String
+	if zero.Add("a") != "a" || zero.Load() != "a" {
+		t.Error(zero.Load())
+	}
+}
+
 func BenchmarkSwapString(b *testing.B) {
 	addr := NewString("")
 	for i := 0; i < b.N; i++ {
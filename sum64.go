@@ -0,0 +1,120 @@
+// Copyright (c) 2020 Meng Huang (mhboy@outlook.com)
+// This package is licensed under a MIT license that can be found in the LICENSE file.
+
+package atomic
+
+import (
+	"math"
+	"runtime"
+	"sync"
+)
+
+// Sum64 represents a compensated float64 sum.
+//
+// Sum64 uses Neumaier's variant of Kahan summation. The running sum and the
+// compensation term are kept in a Complex128, so both are updated together
+// and the rounding error of many small increments does not accumulate.
+type Sum64 struct {
+	v Complex128
+}
+
+// NewSum64 returns a new Sum64.
+func NewSum64(val float64) *Sum64 {
+	addr := &Sum64{}
+	addr.Store(val)
+	return addr
+}
+
+// Add atomically adds delta to *addr and returns the new sum.
+func (addr *Sum64) Add(delta float64) (new float64) {
+	v := addr.v.Update(func(old complex128) complex128 {
+		return neumaierAdd(old, delta)
+	})
+	return real(v) + imag(v)
+}
+
+// Swap atomically stores new into *addr and returns the previous sum.
+func (addr *Sum64) Swap(new float64) (old float64) {
+	v := addr.v.Swap(complex(new, 0))
+	return real(v) + imag(v)
+}
+
+// Load atomically loads the sum.
+func (addr *Sum64) Load() (val float64) {
+	v := addr.v.Load()
+	return real(v) + imag(v)
+}
+
+// Store atomically stores val into *addr, discarding the compensation.
+func (addr *Sum64) Store(val float64) {
+	addr.v.Store(complex(val, 0))
+}
+
+// sumStripe is a Sum64 padded to its own cache line.
+type sumStripe struct {
+	Sum64
+	// pad avoids false sharing between neighbouring stripes.
+	_ [40]byte
+}
+
+// StripedSum64 represents a compensated float64 sum spread over stripes.
+//
+// Concurrent Adds usually land on different stripes, so StripedSum64 scales
+// better than Sum64 under contention, at the cost of a Load that visits
+// every stripe. Load is not a snapshot of concurrent Adds.
+type StripedSum64 struct {
+	stripes []sumStripe
+	next    Uint32
+	hints   sync.Pool
+}
+
+// NewStripedSum64 returns a new StripedSum64 with n stripes.
+// If n <= 0, GOMAXPROCS stripes are used.
+func NewStripedSum64(n int) *StripedSum64 {
+	if n <= 0 {
+		n = runtime.GOMAXPROCS(0)
+	}
+	addr := &StripedSum64{stripes: make([]sumStripe, n)}
+	addr.hints.New = func() interface{} {
+		hint := addr.next.Add(1) - 1
+		return &hint
+	}
+	return addr
+}
+
+// Add atomically adds delta to one of the stripes.
+func (addr *StripedSum64) Add(delta float64) {
+	hint := addr.hints.Get().(*uint32)
+	addr.stripes[*hint%uint32(len(addr.stripes))].Add(delta)
+	addr.hints.Put(hint)
+}
+
+// Load returns the compensated sum of all stripes.
+func (addr *StripedSum64) Load() (val float64) {
+	var sum complex128
+	for i := range addr.stripes {
+		v := addr.stripes[i].v.Load()
+		sum = neumaierAdd(sum, real(v))
+		sum = neumaierAdd(sum, imag(v))
+	}
+	return real(sum) + imag(sum)
+}
+
+// Reset sets every stripe to zero.
+func (addr *StripedSum64) Reset() {
+	for i := range addr.stripes {
+		addr.stripes[i].Store(0)
+	}
+}
+
+// neumaierAdd adds x to the sum real(v) with the compensation imag(v).
+func neumaierAdd(v complex128, x float64) complex128 {
+	sum, c := real(v), imag(v)
+	t := sum + x
+	if math.Abs(sum) >= math.Abs(x) {
+		c += (sum - t) + x
+	} else {
+		c += (x - t) + sum
+	}
+	return complex(t, c)
+}
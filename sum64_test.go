@@ -0,0 +1,118 @@
+// Copyright (c) 2020 Meng Huang (mhboy@outlook.com)
+// This package is licensed under a MIT license that can be found in the LICENSE file.
+
+package atomic
+
+import (
+	"math"
+	"math/big"
+	"sync"
+	"testing"
+)
+
+// referenceSum returns base + n*delta rounded from an exact sum.
+func referenceSum(base, delta float64, n int) float64 {
+	sum := new(big.Float).SetPrec(2048).SetFloat64(base)
+	d := new(big.Float).SetPrec(2048).SetFloat64(delta)
+	sum.Add(sum, new(big.Float).SetPrec(2048).Mul(d, new(big.Float).SetInt64(int64(n))))
+	val, _ := sum.Float64()
+	return val
+}
+
+func TestSum64(t *testing.T) {
+	addr := NewSum64(1)
+	if addr.Load() != 1 {
+		t.Error(addr.Load())
+	}
+	if addr.Add(1e100) != 1e100 {
+		t.Error(addr.Load())
+	}
+	if addr.Add(-1e100) != 1 {
+		t.Error(addr.Load())
+	}
+	if addr.Swap(2) != 1 {
+		t.Error(addr.Load())
+	}
+	addr.Store(0.5)
+	if addr.Load() != 0.5 {
+		t.Error(addr.Load())
+	}
+}
+
+func TestAddSum64(t *testing.T) {
+	const base, delta, n = 1e8, 0.001, 8192 * 16
+	addr := NewSum64(base)
+	var drift = NewFloat64(base)
+	var wg sync.WaitGroup
+	for i := 0; i < 8192; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 16; j++ {
+				addr.Add(delta)
+				drift.Add(delta)
+			}
+		}()
+	}
+	wg.Wait()
+	ref := referenceSum(base, delta, n)
+	if addr.Load() != ref {
+		t.Errorf("%v != %v", addr.Load(), ref)
+	}
+	if math.Abs(drift.Load()-ref) <= math.Abs(addr.Load()-ref) {
+		t.Errorf("Float64 %v is not less accurate than Sum64 %v", drift.Load(), addr.Load())
+	}
+}
+
+func TestStripedSum64(t *testing.T) {
+	const base, delta, n = 1e8, 0.001, 8192 * 16
+	addr := NewStripedSum64(4)
+	addr.Add(base)
+	var wg sync.WaitGroup
+	for i := 0; i < 8192; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 16; j++ {
+				addr.Add(delta)
+			}
+		}()
+	}
+	wg.Wait()
+	ref := referenceSum(base, delta, n)
+	if math.Abs(addr.Load()-ref) > math.Abs(ref)*1e-15 {
+		t.Errorf("%v != %v", addr.Load(), ref)
+	}
+	addr.Reset()
+	if addr.Load() != 0 {
+		t.Error(addr.Load())
+	}
+	if len(NewStripedSum64(0).stripes) == 0 {
+		t.Error("no stripes")
+	}
+}
+
+func BenchmarkAddSum64(b *testing.B) {
+	addr := NewSum64(0)
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			addr.Add(0.1)
+		}
+	})
+}
+
+func BenchmarkAddStripedSum64(b *testing.B) {
+	addr := NewStripedSum64(0)
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			addr.Add(0.1)
+		}
+	})
+}
+
+func BenchmarkLoadSum64(b *testing.B) {
+	addr := NewSum64(0)
+	for i := 0; i < b.N; i++ {
+		addr.Load()
+	}
+}
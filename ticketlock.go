@@ -0,0 +1,49 @@
+// Copyright (c) 2020 Meng Huang (mhboy@outlook.com)
+// This package is licensed under a MIT license that can be found in the LICENSE file.
+
+package atomic
+
+import (
+	"context"
+)
+
+// TicketLock is a fair spin lock. Each Lock takes the next ticket and
+// spins until it is served, so the lock is granted in FIFO order.
+// Only the next waiter in line can take the lock, so it performs poorly
+// when GOMAXPROCS exceeds the number of available CPUs.
+// The zero value for a TicketLock is an unlocked lock.
+type TicketLock struct {
+	next  Uint32
+	owner Uint32
+}
+
+// Lock locks l, spinning until its ticket is served.
+func (l *TicketLock) Lock() {
+	ticket := l.next.Add(1) - 1
+	var b backoff
+	for l.owner.Load() != ticket {
+		b.wait()
+	}
+}
+
+// TryLock tries to lock l and reports whether it succeeded.
+// It only succeeds if no other goroutine holds or waits for the lock.
+func (l *TicketLock) TryLock() bool {
+	owner := l.owner.Load()
+	return l.next.CompareAndSwap(owner, owner+1)
+}
+
+// LockContext locks l, spinning until it is available or ctx is done.
+// A ticket cannot be given back, so LockContext retries TryLock and does
+// not queue fairly with Lock.
+func (l *TicketLock) LockContext(ctx context.Context) error {
+	return lockContext(ctx, l.TryLock)
+}
+
+// Unlock unlocks l and serves the next ticket. It panics if l is not locked.
+func (l *TicketLock) Unlock() {
+	if l.owner.Load() == l.next.Load() {
+		panic("github.com/hslam/atomic: unlock of unlocked TicketLock")
+	}
+	l.owner.Add(1)
+}
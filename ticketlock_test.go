@@ -0,0 +1,39 @@
+// Copyright (c) 2020 Meng Huang (mhboy@outlook.com)
+// This package is licensed under a MIT license that can be found in the LICENSE file.
+
+package atomic
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTicketLock(t *testing.T) {
+	testLocker(t, &TicketLock{})
+}
+
+func TestTicketLockFair(t *testing.T) {
+	l := &TicketLock{}
+	l.Lock()
+	order := make(chan int, 3)
+	for i := 0; i < 3; i++ {
+		go func(i int) {
+			l.Lock()
+			order <- i
+			l.Unlock()
+		}(i)
+		for l.next.Load() != uint32(i+2) {
+			time.Sleep(time.Millisecond)
+		}
+	}
+	l.Unlock()
+	for i := 0; i < 3; i++ {
+		if j := <-order; j != i {
+			t.Error(i, j)
+		}
+	}
+}
+
+func BenchmarkTicketLock(b *testing.B) {
+	benchmarkLocker(b, &TicketLock{})
+}
@@ -0,0 +1,132 @@
+// Copyright (c) 2020 Meng Huang (mhboy@outlook.com)
+// This package is licensed under a MIT license that can be found in the LICENSE file.
+
+package atomic
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrExceedsLimit is returned by WaitN when n exceeds the burst or limit of a rate limiter.
+var ErrExceedsLimit = errors.New("github.com/hslam/atomic: n exceeds the limit")
+
+// TokenBucket is a lock-free token bucket rate limiter.
+//
+// Tokens are added at a fixed rate up to burst. The whole state is packed
+// into a single Int64 holding the instant, in nanoseconds, at which the
+// bucket will be full again: the number of tokens at time now is
+// burst - (state - now) / interval, or burst once state is not after now.
+// Taking tokens moves the instant forward with a CompareAndSwap, so refills
+// never need a background goroutine.
+type TokenBucket struct {
+	state    Int64
+	interval int64
+	burst    int64
+	clock    Clock
+}
+
+// NewTokenBucket returns a new TokenBucket that allows rate events per second
+// with bursts of up to burst events. The bucket starts full.
+// If clock is nil, the system clock is used.
+func NewTokenBucket(rate float64, burst int, clock Clock) *TokenBucket {
+	if rate <= 0 {
+		panic("github.com/hslam/atomic: non-positive rate")
+	}
+	if burst <= 0 {
+		panic("github.com/hslam/atomic: non-positive burst")
+	}
+	interval := int64(float64(time.Second) / rate)
+	if interval < 1 {
+		interval = 1
+	}
+	return &TokenBucket{interval: interval, burst: int64(burst), clock: clockOrSystem(clock)}
+}
+
+// Allow is shorthand for AllowN(1).
+func (tb *TokenBucket) Allow() bool {
+	return tb.AllowN(1)
+}
+
+// AllowN reports whether n events may happen now, taking n tokens if so.
+func (tb *TokenBucket) AllowN(n int) bool {
+	now := tb.now()
+	for {
+		old := tb.state.Load()
+		next := tb.take(old, now, n)
+		if next-now > tb.burst*tb.interval {
+			return false
+		}
+		if tb.state.CompareAndSwap(old, next) {
+			return true
+		}
+	}
+}
+
+// Reserve is shorthand for ReserveN(1).
+func (tb *TokenBucket) Reserve() (delay time.Duration, ok bool) {
+	return tb.ReserveN(1)
+}
+
+// ReserveN takes n tokens, borrowing from future refills if necessary, and
+// returns how long the caller must wait before the n events may happen.
+// It returns false if n exceeds the burst.
+func (tb *TokenBucket) ReserveN(n int) (delay time.Duration, ok bool) {
+	if int64(n) > tb.burst {
+		return 0, false
+	}
+	now := tb.now()
+	for {
+		old := tb.state.Load()
+		next := tb.take(old, now, n)
+		if tb.state.CompareAndSwap(old, next) {
+			delay = time.Duration(next - now - tb.burst*tb.interval)
+			if delay < 0 {
+				delay = 0
+			}
+			return delay, true
+		}
+	}
+}
+
+// Wait is shorthand for WaitN(ctx, 1).
+func (tb *TokenBucket) Wait(ctx context.Context) error {
+	return tb.WaitN(ctx, 1)
+}
+
+// WaitN blocks until n events may happen. It returns an error if n exceeds
+// the burst, or if ctx is done or its deadline would pass before then, in
+// which case the reserved tokens are returned to the bucket.
+func (tb *TokenBucket) WaitN(ctx context.Context, n int) error {
+	delay, ok := tb.ReserveN(n)
+	if !ok {
+		return ErrExceedsLimit
+	}
+	return sleep(ctx, tb.clock, delay, func() {
+		tb.state.Add(-int64(n) * tb.interval)
+	})
+}
+
+// Tokens returns the number of tokens available now.
+// It is negative while reservations are borrowing from future refills.
+func (tb *TokenBucket) Tokens() float64 {
+	now := tb.now()
+	next := tb.state.Load()
+	if next < now {
+		next = now
+	}
+	return float64(tb.burst) - float64(next-now)/float64(tb.interval)
+}
+
+// take returns the state after taking n tokens from the state old at now.
+func (tb *TokenBucket) take(old, now int64, n int) int64 {
+	if old < now {
+		old = now
+	}
+	return old + int64(n)*tb.interval
+}
+
+func (tb *TokenBucket) now() int64 {
+	return tb.clock.Now().UnixNano()
+}
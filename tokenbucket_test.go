@@ -0,0 +1,134 @@
+// Copyright (c) 2020 Meng Huang (mhboy@outlook.com)
+// This package is licensed under a MIT license that can be found in the LICENSE file.
+
+package atomic
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestTokenBucket(t *testing.T) {
+	clock := newFakeClock()
+	tb := NewTokenBucket(10, 5, clock)
+	if tb.Tokens() != 5 {
+		t.Error(tb.Tokens())
+	}
+	if !tb.AllowN(5) {
+		t.Error("should allow the burst")
+	}
+	if tb.Allow() {
+		t.Error("should be empty")
+	}
+	clock.Advance(time.Millisecond * 100)
+	if !tb.Allow() {
+		t.Error("should refill one token")
+	}
+	if tb.Allow() {
+		t.Error("should be empty")
+	}
+	clock.Advance(time.Hour)
+	if tb.Tokens() != 5 {
+		t.Error("should not refill beyond the burst", tb.Tokens())
+	}
+	if tb.AllowN(6) {
+		t.Error("should not allow more than the burst")
+	}
+}
+
+func TestTokenBucketReserve(t *testing.T) {
+	clock := newFakeClock()
+	tb := NewTokenBucket(10, 2, clock)
+	if _, ok := tb.ReserveN(3); ok {
+		t.Error("should not reserve more than the burst")
+	}
+	for i, want := range []time.Duration{0, 0, time.Millisecond * 100, time.Millisecond * 200} {
+		if delay, ok := tb.Reserve(); !ok || delay != want {
+			t.Error(i, delay, ok)
+		}
+	}
+	if tb.Tokens() != -2 {
+		t.Error(tb.Tokens())
+	}
+	clock.Advance(time.Millisecond * 200)
+	if tb.Tokens() != 0 {
+		t.Error(tb.Tokens())
+	}
+}
+
+func TestTokenBucketWait(t *testing.T) {
+	clock := newFakeClock()
+	tb := NewTokenBucket(1, 1, clock)
+	if err := tb.Wait(context.Background()); err != nil {
+		t.Error(err)
+	}
+	if err := tb.WaitN(context.Background(), 2); err != ErrExceedsLimit {
+		t.Error(err)
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- tb.Wait(context.Background())
+	}()
+	clock.WaitTimers(1)
+	clock.Advance(time.Second / 2)
+	select {
+	case err := <-done:
+		t.Error("should wait", err)
+	default:
+	}
+	clock.Advance(time.Second / 2)
+	if err := <-done; err != nil {
+		t.Error(err)
+	}
+	// The deadline is earlier than the delay of one second on the fake clock.
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second/10)
+	defer cancel()
+	if err := tb.Wait(ctx); err != context.DeadlineExceeded {
+		t.Error(err)
+	}
+	if tb.Tokens() != 0 {
+		t.Error("should return the canceled token", tb.Tokens())
+	}
+}
+
+func TestTokenBucketConcurrent(t *testing.T) {
+	clock := newFakeClock()
+	tb := NewTokenBucket(1, 100, clock)
+	var allowed Int64
+	var wg sync.WaitGroup
+	for i := 0; i < 1000; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if tb.Allow() {
+				allowed.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	if allowed.Load() != 100 {
+		t.Error(allowed.Load())
+	}
+}
+
+func TestTokenBucketPanic(t *testing.T) {
+	for _, args := range [][2]float64{{0, 1}, {1, 0}} {
+		func() {
+			defer func() {
+				if err := recover(); err == nil {
+					t.Error("should panic")
+				}
+			}()
+			NewTokenBucket(args[0], int(args[1]), nil)
+		}()
+	}
+}
+
+func BenchmarkAllowTokenBucket(b *testing.B) {
+	tb := NewTokenBucket(1e9, 1<<30, nil)
+	for i := 0; i < b.N; i++ {
+		tb.Allow()
+	}
+}
@@ -4,6 +4,7 @@
 package atomic
 
 import (
+	"math"
 	"sync/atomic"
 )
 
@@ -41,6 +42,89 @@ func (addr *Uint16) Add(delta uint16) (new uint16) {
 	}
 }
 
+// FetchAdd atomically adds delta to *addr and returns the previous value.
+func (addr *Uint16) FetchAdd(delta uint16) (old uint16) {
+	for {
+		old = addr.Load()
+		if addr.CompareAndSwap(old, old+delta) {
+			return
+		}
+	}
+}
+
+// FetchSub atomically subtracts delta from *addr and returns the previous value.
+func (addr *Uint16) FetchSub(delta uint16) (old uint16) {
+	for {
+		old = addr.Load()
+		if addr.CompareAndSwap(old, old-delta) {
+			return
+		}
+	}
+}
+
+// FetchAnd atomically replaces *addr with the bitwise AND of *addr and mask
+// and returns the previous value.
+func (addr *Uint16) FetchAnd(mask uint16) (old uint16) {
+	for {
+		old = addr.Load()
+		if addr.CompareAndSwap(old, old&mask) {
+			return
+		}
+	}
+}
+
+// FetchOr atomically replaces *addr with the bitwise OR of *addr and mask
+// and returns the previous value.
+func (addr *Uint16) FetchOr(mask uint16) (old uint16) {
+	for {
+		old = addr.Load()
+		if addr.CompareAndSwap(old, old|mask) {
+			return
+		}
+	}
+}
+
+// CompareAndExchange executes the compare-and-swap operation for an uint16 value
+// and returns the value of *addr it was compared with, which is old if the swap happened.
+func (addr *Uint16) CompareAndExchange(old, new uint16) (val uint16) {
+	for {
+		if addr.CompareAndSwap(old, new) {
+			return old
+		}
+		if val = addr.Load(); val != old {
+			return
+		}
+	}
+}
+
+// StoreMax atomically stores val into *addr if val is greater than *addr,
+// and returns the resulting value and whether it changed.
+func (addr *Uint16) StoreMax(val uint16) (new uint16, changed bool) {
+	for {
+		old := addr.Load()
+		if val <= old {
+			return old, false
+		}
+		if addr.CompareAndSwap(old, val) {
+			return val, true
+		}
+	}
+}
+
+// StoreMin atomically stores val into *addr if val is less than *addr,
+// and returns the resulting value and whether it changed.
+func (addr *Uint16) StoreMin(val uint16) (new uint16, changed bool) {
+	for {
+		old := addr.Load()
+		if val >= old {
+			return old, false
+		}
+		if addr.CompareAndSwap(old, val) {
+			return val, true
+		}
+	}
+}
+
 // Load atomically loads *addr.
 func (addr *Uint16) Load() (val uint16) {
 	var v = atomic.LoadUint32(&addr.v)
@@ -51,3 +135,73 @@ func (addr *Uint16) Load() (val uint16) {
 func (addr *Uint16) Store(val uint16) {
 	atomic.StoreUint32(&addr.v, uint32(val))
 }
+
+// AddSaturating atomically adds delta to *addr, clamping the result to the
+// range of uint16, and returns the new value.
+func (addr *Uint16) AddSaturating(delta uint16) (new uint16) {
+	for {
+		old := addr.Load()
+		val, _ := addUint64(uint64(old), uint64(delta), 0, math.MaxUint16)
+		new = uint16(val)
+		if new == old || addr.CompareAndSwap(old, new) {
+			return
+		}
+	}
+}
+
+// AddChecked atomically adds delta to *addr and returns the new value.
+// If the result overflows uint16, *addr is left unchanged and ok is false.
+func (addr *Uint16) AddChecked(delta uint16) (new uint16, ok bool) {
+	return addr.AddBounded(delta, 0, math.MaxUint16)
+}
+
+// AddBounded atomically adds delta to *addr and returns the new value.
+// If the result falls outside [lo, hi], *addr is left unchanged and ok is false.
+func (addr *Uint16) AddBounded(delta, lo, hi uint16) (new uint16, ok bool) {
+	for {
+		old := addr.Load()
+		val, ok := addUint64(uint64(old), uint64(delta), uint64(lo), uint64(hi))
+		if !ok {
+			return old, false
+		}
+		new = uint16(val)
+		if addr.CompareAndSwap(old, new) {
+			return new, true
+		}
+	}
+}
+
+// SubSaturating atomically subtracts delta from *addr, clamping the result to the
+// range of uint16, and returns the new value.
+func (addr *Uint16) SubSaturating(delta uint16) (new uint16) {
+	for {
+		old := addr.Load()
+		val, _ := subUint64(uint64(old), uint64(delta), 0, math.MaxUint16)
+		new = uint16(val)
+		if new == old || addr.CompareAndSwap(old, new) {
+			return
+		}
+	}
+}
+
+// SubChecked atomically subtracts delta from *addr and returns the new value.
+// If the result overflows uint16, *addr is left unchanged and ok is false.
+func (addr *Uint16) SubChecked(delta uint16) (new uint16, ok bool) {
+	return addr.SubBounded(delta, 0, math.MaxUint16)
+}
+
+// SubBounded atomically subtracts delta from *addr and returns the new value.
+// If the result falls outside [lo, hi], *addr is left unchanged and ok is false.
+func (addr *Uint16) SubBounded(delta, lo, hi uint16) (new uint16, ok bool) {
+	for {
+		old := addr.Load()
+		val, ok := subUint64(uint64(old), uint64(delta), uint64(lo), uint64(hi))
+		if !ok {
+			return old, false
+		}
+		new = uint16(val)
+		if addr.CompareAndSwap(old, new) {
+			return new, true
+		}
+	}
+}
@@ -4,7 +4,10 @@
 package atomic
 
 import (
+	"context"
+	"math"
 	"sync/atomic"
+	"unsafe"
 )
 
 // Uint32 represents an uint32.
@@ -34,6 +37,62 @@ func (addr *Uint32) Add(delta uint32) (new uint32) {
 	return atomic.AddUint32(&addr.v, delta)
 }
 
+// FetchAdd atomically adds delta to *addr and returns the previous value.
+func (addr *Uint32) FetchAdd(delta uint32) (old uint32) {
+	return FetchAddUint32(&addr.v, delta)
+}
+
+// FetchSub atomically subtracts delta from *addr and returns the previous value.
+func (addr *Uint32) FetchSub(delta uint32) (old uint32) {
+	return FetchSubUint32(&addr.v, delta)
+}
+
+// FetchAnd atomically replaces *addr with the bitwise AND of *addr and mask
+// and returns the previous value.
+func (addr *Uint32) FetchAnd(mask uint32) (old uint32) {
+	return FetchAndUint32(&addr.v, mask)
+}
+
+// FetchOr atomically replaces *addr with the bitwise OR of *addr and mask
+// and returns the previous value.
+func (addr *Uint32) FetchOr(mask uint32) (old uint32) {
+	return FetchOrUint32(&addr.v, mask)
+}
+
+// CompareAndExchange executes the compare-and-swap operation for an uint32 value
+// and returns the value of *addr it was compared with, which is old if the swap happened.
+func (addr *Uint32) CompareAndExchange(old, new uint32) (val uint32) {
+	return CompareAndExchangeUint32(&addr.v, old, new)
+}
+
+// StoreMax atomically stores val into *addr if val is greater than *addr,
+// and returns the resulting value and whether it changed.
+func (addr *Uint32) StoreMax(val uint32) (new uint32, changed bool) {
+	for {
+		old := addr.Load()
+		if val <= old {
+			return old, false
+		}
+		if addr.CompareAndSwap(old, val) {
+			return val, true
+		}
+	}
+}
+
+// StoreMin atomically stores val into *addr if val is less than *addr,
+// and returns the resulting value and whether it changed.
+func (addr *Uint32) StoreMin(val uint32) (new uint32, changed bool) {
+	for {
+		old := addr.Load()
+		if val >= old {
+			return old, false
+		}
+		if addr.CompareAndSwap(old, val) {
+			return val, true
+		}
+	}
+}
+
 // Load atomically loads *addr.
 func (addr *Uint32) Load() (val uint32) {
 	return atomic.LoadUint32(&addr.v)
@@ -43,3 +102,100 @@ func (addr *Uint32) Load() (val uint32) {
 func (addr *Uint32) Store(val uint32) {
 	atomic.StoreUint32(&addr.v, val)
 }
+
+// Wait parks the calling goroutine while *addr == old until Wake or WakeAll
+// is called, and returns immediately if *addr != old. Like a futex, Wait may
+// return early, so callers must re-check *addr in a loop.
+func (addr *Uint32) Wait(old uint32) {
+	park(context.Background(), unsafe.Pointer(&addr.v), func() bool { return addr.Load() == old })
+}
+
+// WaitContext is like Wait but returns ctx.Err() if ctx is done before
+// the goroutine is woken.
+func (addr *Uint32) WaitContext(ctx context.Context, old uint32) error {
+	return park(ctx, unsafe.Pointer(&addr.v), func() bool { return addr.Load() == old })
+}
+
+// Wake wakes up to n goroutines blocked in Wait on addr and returns how many were woken.
+// Callers should change *addr before calling Wake.
+func (addr *Uint32) Wake(n int) (woken int) {
+	if n <= 0 {
+		return 0
+	}
+	return unpark(unsafe.Pointer(&addr.v), n)
+}
+
+// WakeAll wakes all goroutines blocked in Wait on addr and returns how many were woken.
+func (addr *Uint32) WakeAll() (woken int) {
+	return unpark(unsafe.Pointer(&addr.v), -1)
+}
+
+// AddSaturating atomically adds delta to *addr, clamping the result to the
+// range of uint32, and returns the new value.
+func (addr *Uint32) AddSaturating(delta uint32) (new uint32) {
+	for {
+		old := addr.Load()
+		val, _ := addUint64(uint64(old), uint64(delta), 0, math.MaxUint32)
+		new = uint32(val)
+		if new == old || addr.CompareAndSwap(old, new) {
+			return
+		}
+	}
+}
+
+// AddChecked atomically adds delta to *addr and returns the new value.
+// If the result overflows uint32, *addr is left unchanged and ok is false.
+func (addr *Uint32) AddChecked(delta uint32) (new uint32, ok bool) {
+	return addr.AddBounded(delta, 0, math.MaxUint32)
+}
+
+// AddBounded atomically adds delta to *addr and returns the new value.
+// If the result falls outside [lo, hi], *addr is left unchanged and ok is false.
+func (addr *Uint32) AddBounded(delta, lo, hi uint32) (new uint32, ok bool) {
+	for {
+		old := addr.Load()
+		val, ok := addUint64(uint64(old), uint64(delta), uint64(lo), uint64(hi))
+		if !ok {
+			return old, false
+		}
+		new = uint32(val)
+		if addr.CompareAndSwap(old, new) {
+			return new, true
+		}
+	}
+}
+
+// SubSaturating atomically subtracts delta from *addr, clamping the result to the
+// range of uint32, and returns the new value.
+func (addr *Uint32) SubSaturating(delta uint32) (new uint32) {
+	for {
+		old := addr.Load()
+		val, _ := subUint64(uint64(old), uint64(delta), 0, math.MaxUint32)
+		new = uint32(val)
+		if new == old || addr.CompareAndSwap(old, new) {
+			return
+		}
+	}
+}
+
+// SubChecked atomically subtracts delta from *addr and returns the new value.
+// If the result overflows uint32, *addr is left unchanged and ok is false.
+func (addr *Uint32) SubChecked(delta uint32) (new uint32, ok bool) {
+	return addr.SubBounded(delta, 0, math.MaxUint32)
+}
+
+// SubBounded atomically subtracts delta from *addr and returns the new value.
+// If the result falls outside [lo, hi], *addr is left unchanged and ok is false.
+func (addr *Uint32) SubBounded(delta, lo, hi uint32) (new uint32, ok bool) {
+	for {
+		old := addr.Load()
+		val, ok := subUint64(uint64(old), uint64(delta), uint64(lo), uint64(hi))
+		if !ok {
+			return old, false
+		}
+		new = uint32(val)
+		if addr.CompareAndSwap(old, new) {
+			return new, true
+		}
+	}
+}
@@ -4,6 +4,7 @@
 package atomic
 
 import (
+	"math"
 	"sync/atomic"
 )
 
@@ -34,6 +35,62 @@ func (addr *Uint64) Add(delta uint64) (new uint64) {
 	return atomic.AddUint64(&addr.v, delta)
 }
 
+// FetchAdd atomically adds delta to *addr and returns the previous value.
+func (addr *Uint64) FetchAdd(delta uint64) (old uint64) {
+	return FetchAddUint64(&addr.v, delta)
+}
+
+// FetchSub atomically subtracts delta from *addr and returns the previous value.
+func (addr *Uint64) FetchSub(delta uint64) (old uint64) {
+	return FetchSubUint64(&addr.v, delta)
+}
+
+// FetchAnd atomically replaces *addr with the bitwise AND of *addr and mask
+// and returns the previous value.
+func (addr *Uint64) FetchAnd(mask uint64) (old uint64) {
+	return FetchAndUint64(&addr.v, mask)
+}
+
+// FetchOr atomically replaces *addr with the bitwise OR of *addr and mask
+// and returns the previous value.
+func (addr *Uint64) FetchOr(mask uint64) (old uint64) {
+	return FetchOrUint64(&addr.v, mask)
+}
+
+// CompareAndExchange executes the compare-and-swap operation for an uint64 value
+// and returns the value of *addr it was compared with, which is old if the swap happened.
+func (addr *Uint64) CompareAndExchange(old, new uint64) (val uint64) {
+	return CompareAndExchangeUint64(&addr.v, old, new)
+}
+
+// StoreMax atomically stores val into *addr if val is greater than *addr,
+// and returns the resulting value and whether it changed.
+func (addr *Uint64) StoreMax(val uint64) (new uint64, changed bool) {
+	for {
+		old := addr.Load()
+		if val <= old {
+			return old, false
+		}
+		if addr.CompareAndSwap(old, val) {
+			return val, true
+		}
+	}
+}
+
+// StoreMin atomically stores val into *addr if val is less than *addr,
+// and returns the resulting value and whether it changed.
+func (addr *Uint64) StoreMin(val uint64) (new uint64, changed bool) {
+	for {
+		old := addr.Load()
+		if val >= old {
+			return old, false
+		}
+		if addr.CompareAndSwap(old, val) {
+			return val, true
+		}
+	}
+}
+
 // Load atomically loads *addr.
 func (addr *Uint64) Load() (val uint64) {
 	return atomic.LoadUint64(&addr.v)
@@ -43,3 +100,73 @@ func (addr *Uint64) Load() (val uint64) {
 func (addr *Uint64) Store(val uint64) {
 	atomic.StoreUint64(&addr.v, val)
 }
+
+// AddSaturating atomically adds delta to *addr, clamping the result to the
+// range of uint64, and returns the new value.
+func (addr *Uint64) AddSaturating(delta uint64) (new uint64) {
+	for {
+		old := addr.Load()
+		val, _ := addUint64(old, delta, 0, math.MaxUint64)
+		new = uint64(val)
+		if new == old || addr.CompareAndSwap(old, new) {
+			return
+		}
+	}
+}
+
+// AddChecked atomically adds delta to *addr and returns the new value.
+// If the result overflows uint64, *addr is left unchanged and ok is false.
+func (addr *Uint64) AddChecked(delta uint64) (new uint64, ok bool) {
+	return addr.AddBounded(delta, 0, math.MaxUint64)
+}
+
+// AddBounded atomically adds delta to *addr and returns the new value.
+// If the result falls outside [lo, hi], *addr is left unchanged and ok is false.
+func (addr *Uint64) AddBounded(delta, lo, hi uint64) (new uint64, ok bool) {
+	for {
+		old := addr.Load()
+		val, ok := addUint64(old, delta, lo, hi)
+		if !ok {
+			return old, false
+		}
+		new = uint64(val)
+		if addr.CompareAndSwap(old, new) {
+			return new, true
+		}
+	}
+}
+
+// SubSaturating atomically subtracts delta from *addr, clamping the result to the
+// range of uint64, and returns the new value.
+func (addr *Uint64) SubSaturating(delta uint64) (new uint64) {
+	for {
+		old := addr.Load()
+		val, _ := subUint64(old, delta, 0, math.MaxUint64)
+		new = uint64(val)
+		if new == old || addr.CompareAndSwap(old, new) {
+			return
+		}
+	}
+}
+
+// SubChecked atomically subtracts delta from *addr and returns the new value.
+// If the result overflows uint64, *addr is left unchanged and ok is false.
+func (addr *Uint64) SubChecked(delta uint64) (new uint64, ok bool) {
+	return addr.SubBounded(delta, 0, math.MaxUint64)
+}
+
+// SubBounded atomically subtracts delta from *addr and returns the new value.
+// If the result falls outside [lo, hi], *addr is left unchanged and ok is false.
+func (addr *Uint64) SubBounded(delta, lo, hi uint64) (new uint64, ok bool) {
+	for {
+		old := addr.Load()
+		val, ok := subUint64(old, delta, lo, hi)
+		if !ok {
+			return old, false
+		}
+		new = uint64(val)
+		if addr.CompareAndSwap(old, new) {
+			return new, true
+		}
+	}
+}
@@ -4,6 +4,7 @@
 package atomic
 
 import (
+	"math"
 	"sync"
 	"testing"
 )
@@ -77,6 +78,163 @@ func TestSwapUint64(t *testing.T) {
 	wg.Wait()
 }
 
+func TestSaturatingUint64(t *testing.T) {
+	addr := NewUint64(math.MaxUint64 - 1)
+	if addr.AddSaturating(2) != math.MaxUint64 || addr.Load() != math.MaxUint64 {
+		t.Error(addr.Load())
+	}
+	if addr.AddSaturating(math.MaxUint64) != math.MaxUint64 {
+		t.Error(addr.Load())
+	}
+	addr.Store(1)
+	if addr.SubSaturating(2) != 0 || addr.Load() != 0 {
+		t.Error(addr.Load())
+	}
+	if addr.SubSaturating(math.MaxUint64) != 0 {
+		t.Error(addr.Load())
+	}
+}
+
+func TestCheckedUint64(t *testing.T) {
+	addr := NewUint64(math.MaxUint64 - 1)
+	if new, ok := addr.AddChecked(1); !ok || new != math.MaxUint64 {
+		t.Error(new, ok)
+	}
+	if new, ok := addr.AddChecked(1); ok || new != math.MaxUint64 || addr.Load() != math.MaxUint64 {
+		t.Error(new, ok)
+	}
+	addr.Store(1)
+	if new, ok := addr.SubChecked(1); !ok || new != 0 {
+		t.Error(new, ok)
+	}
+	if new, ok := addr.SubChecked(1); ok || new != 0 || addr.Load() != 0 {
+		t.Error(new, ok)
+	}
+}
+
+func TestBoundedUint64(t *testing.T) {
+	addr := NewUint64(2)
+	if new, ok := addr.AddBounded(3, 2, 5); !ok || new != 5 {
+		t.Error(new, ok)
+	}
+	if new, ok := addr.AddBounded(1, 2, 5); ok || new != 5 {
+		t.Error(new, ok)
+	}
+	if new, ok := addr.SubBounded(3, 2, 5); !ok || new != 2 {
+		t.Error(new, ok)
+	}
+	if new, ok := addr.SubBounded(1, 2, 5); ok || new != 2 {
+		t.Error(new, ok)
+	}
+	if new, ok := addr.SubBounded(3, 0, 5); ok || new != 2 {
+		t.Error(new, ok)
+	}
+}
+
+func TestAddBoundedUint64(t *testing.T) {
+	addr := NewUint64(0)
+	var wg sync.WaitGroup
+	for i := 0; i < 8192; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			addr.AddBounded(1, 0, 100)
+		}()
+	}
+	wg.Wait()
+	if addr.Load() != 100 {
+		t.Error(addr.Load())
+	}
+}
+
+func TestFetchUint64(t *testing.T) {
+	addr := NewUint64(6)
+	if addr.FetchAdd(2) != 6 || addr.Load() != 8 {
+		t.Error(addr.Load())
+	}
+	if addr.FetchSub(3) != 8 || addr.Load() != 5 {
+		t.Error(addr.Load())
+	}
+	if addr.FetchAnd(6) != 5 || addr.Load() != 4 {
+		t.Error(addr.Load())
+	}
+	if addr.FetchOr(3) != 4 || addr.Load() != 7 {
+		t.Error(addr.Load())
+	}
+	if addr.CompareAndExchange(7, 9) != 7 || addr.Load() != 9 {
+		t.Error(addr.Load())
+	}
+	if addr.CompareAndExchange(7, 10) != 9 || addr.Load() != 9 {
+		t.Error(addr.Load())
+	}
+}
+
+func TestFetchAddUint64(t *testing.T) {
+	addr := NewUint64(0)
+	var seen [64]Bool
+	var wg sync.WaitGroup
+	for i := 0; i < 64; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if seen[addr.FetchAdd(1)].Swap(true) {
+				t.Error("duplicate")
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestStoreMaxUint64(t *testing.T) {
+	addr := NewUint64(5)
+	if new, changed := addr.StoreMax(3); changed || new != 5 {
+		t.Error(new, changed)
+	}
+	if new, changed := addr.StoreMax(5); changed || new != 5 {
+		t.Error(new, changed)
+	}
+	if new, changed := addr.StoreMax(7); !changed || new != 7 || addr.Load() != 7 {
+		t.Error(new, changed)
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i uint64) {
+			defer wg.Done()
+			addr.StoreMax(i)
+		}(uint64(i))
+	}
+	wg.Wait()
+	if addr.Load() != 99 {
+		t.Error(addr.Load())
+	}
+}
+
+func TestStoreMinUint64(t *testing.T) {
+	addr := NewUint64(5)
+	if new, changed := addr.StoreMin(7); changed || new != 5 {
+		t.Error(new, changed)
+	}
+	if new, changed := addr.StoreMin(5); changed || new != 5 {
+		t.Error(new, changed)
+	}
+	if new, changed := addr.StoreMin(3); !changed || new != 3 || addr.Load() != 3 {
+		t.Error(new, changed)
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i uint64) {
+			defer wg.Done()
+			addr.StoreMin(i)
+		}(uint64(i))
+	}
+	wg.Wait()
+	if addr.Load() != 0 {
+		t.Error(addr.Load())
+	}
+}
+
 func BenchmarkSwapUint64(b *testing.B) {
 	addr := NewUint64(1)
 	for i := 0; i < b.N; i++ {
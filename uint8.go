@@ -4,6 +4,7 @@
 package atomic
 
 import (
+	"math"
 	"sync/atomic"
 )
 
@@ -41,6 +42,89 @@ func (addr *Uint8) Add(delta uint8) (new uint8) {
 	}
 }
 
+// FetchAdd atomically adds delta to *addr and returns the previous value.
+func (addr *Uint8) FetchAdd(delta uint8) (old uint8) {
+	for {
+		old = addr.Load()
+		if addr.CompareAndSwap(old, old+delta) {
+			return
+		}
+	}
+}
+
+// FetchSub atomically subtracts delta from *addr and returns the previous value.
+func (addr *Uint8) FetchSub(delta uint8) (old uint8) {
+	for {
+		old = addr.Load()
+		if addr.CompareAndSwap(old, old-delta) {
+			return
+		}
+	}
+}
+
+// FetchAnd atomically replaces *addr with the bitwise AND of *addr and mask
+// and returns the previous value.
+func (addr *Uint8) FetchAnd(mask uint8) (old uint8) {
+	for {
+		old = addr.Load()
+		if addr.CompareAndSwap(old, old&mask) {
+			return
+		}
+	}
+}
+
+// FetchOr atomically replaces *addr with the bitwise OR of *addr and mask
+// and returns the previous value.
+func (addr *Uint8) FetchOr(mask uint8) (old uint8) {
+	for {
+		old = addr.Load()
+		if addr.CompareAndSwap(old, old|mask) {
+			return
+		}
+	}
+}
+
+// CompareAndExchange executes the compare-and-swap operation for an uint8 value
+// and returns the value of *addr it was compared with, which is old if the swap happened.
+func (addr *Uint8) CompareAndExchange(old, new uint8) (val uint8) {
+	for {
+		if addr.CompareAndSwap(old, new) {
+			return old
+		}
+		if val = addr.Load(); val != old {
+			return
+		}
+	}
+}
+
+// StoreMax atomically stores val into *addr if val is greater than *addr,
+// and returns the resulting value and whether it changed.
+func (addr *Uint8) StoreMax(val uint8) (new uint8, changed bool) {
+	for {
+		old := addr.Load()
+		if val <= old {
+			return old, false
+		}
+		if addr.CompareAndSwap(old, val) {
+			return val, true
+		}
+	}
+}
+
+// StoreMin atomically stores val into *addr if val is less than *addr,
+// and returns the resulting value and whether it changed.
+func (addr *Uint8) StoreMin(val uint8) (new uint8, changed bool) {
+	for {
+		old := addr.Load()
+		if val >= old {
+			return old, false
+		}
+		if addr.CompareAndSwap(old, val) {
+			return val, true
+		}
+	}
+}
+
 // Load atomically loads *addr.
 func (addr *Uint8) Load() (val uint8) {
 	var v = atomic.LoadUint32(&addr.v)
@@ -51,3 +135,73 @@ func (addr *Uint8) Load() (val uint8) {
 func (addr *Uint8) Store(val uint8) {
 	atomic.StoreUint32(&addr.v, uint32(val))
 }
+
+// AddSaturating atomically adds delta to *addr, clamping the result to the
+// range of uint8, and returns the new value.
+func (addr *Uint8) AddSaturating(delta uint8) (new uint8) {
+	for {
+		old := addr.Load()
+		val, _ := addUint64(uint64(old), uint64(delta), 0, math.MaxUint8)
+		new = uint8(val)
+		if new == old || addr.CompareAndSwap(old, new) {
+			return
+		}
+	}
+}
+
+// AddChecked atomically adds delta to *addr and returns the new value.
+// If the result overflows uint8, *addr is left unchanged and ok is false.
+func (addr *Uint8) AddChecked(delta uint8) (new uint8, ok bool) {
+	return addr.AddBounded(delta, 0, math.MaxUint8)
+}
+
+// AddBounded atomically adds delta to *addr and returns the new value.
+// If the result falls outside [lo, hi], *addr is left unchanged and ok is false.
+func (addr *Uint8) AddBounded(delta, lo, hi uint8) (new uint8, ok bool) {
+	for {
+		old := addr.Load()
+		val, ok := addUint64(uint64(old), uint64(delta), uint64(lo), uint64(hi))
+		if !ok {
+			return old, false
+		}
+		new = uint8(val)
+		if addr.CompareAndSwap(old, new) {
+			return new, true
+		}
+	}
+}
+
+// SubSaturating atomically subtracts delta from *addr, clamping the result to the
+// range of uint8, and returns the new value.
+func (addr *Uint8) SubSaturating(delta uint8) (new uint8) {
+	for {
+		old := addr.Load()
+		val, _ := subUint64(uint64(old), uint64(delta), 0, math.MaxUint8)
+		new = uint8(val)
+		if new == old || addr.CompareAndSwap(old, new) {
+			return
+		}
+	}
+}
+
+// SubChecked atomically subtracts delta from *addr and returns the new value.
+// If the result overflows uint8, *addr is left unchanged and ok is false.
+func (addr *Uint8) SubChecked(delta uint8) (new uint8, ok bool) {
+	return addr.SubBounded(delta, 0, math.MaxUint8)
+}
+
+// SubBounded atomically subtracts delta from *addr and returns the new value.
+// If the result falls outside [lo, hi], *addr is left unchanged and ok is false.
+func (addr *Uint8) SubBounded(delta, lo, hi uint8) (new uint8, ok bool) {
+	for {
+		old := addr.Load()
+		val, ok := subUint64(uint64(old), uint64(delta), uint64(lo), uint64(hi))
+		if !ok {
+			return old, false
+		}
+		new = uint8(val)
+		if addr.CompareAndSwap(old, new) {
+			return new, true
+		}
+	}
+}
@@ -4,6 +4,7 @@
 package atomic
 
 import (
+	"math"
 	"sync"
 	"testing"
 )
@@ -77,6 +78,163 @@ func TestSwapUint8(t *testing.T) {
 	wg.Wait()
 }
 
+func TestSaturatingUint8(t *testing.T) {
+	addr := NewUint8(math.MaxUint8 - 1)
+	if addr.AddSaturating(2) != math.MaxUint8 || addr.Load() != math.MaxUint8 {
+		t.Error(addr.Load())
+	}
+	if addr.AddSaturating(math.MaxUint8) != math.MaxUint8 {
+		t.Error(addr.Load())
+	}
+	addr.Store(1)
+	if addr.SubSaturating(2) != 0 || addr.Load() != 0 {
+		t.Error(addr.Load())
+	}
+	if addr.SubSaturating(math.MaxUint8) != 0 {
+		t.Error(addr.Load())
+	}
+}
+
+func TestCheckedUint8(t *testing.T) {
+	addr := NewUint8(math.MaxUint8 - 1)
+	if new, ok := addr.AddChecked(1); !ok || new != math.MaxUint8 {
+		t.Error(new, ok)
+	}
+	if new, ok := addr.AddChecked(1); ok || new != math.MaxUint8 || addr.Load() != math.MaxUint8 {
+		t.Error(new, ok)
+	}
+	addr.Store(1)
+	if new, ok := addr.SubChecked(1); !ok || new != 0 {
+		t.Error(new, ok)
+	}
+	if new, ok := addr.SubChecked(1); ok || new != 0 || addr.Load() != 0 {
+		t.Error(new, ok)
+	}
+}
+
+func TestBoundedUint8(t *testing.T) {
+	addr := NewUint8(2)
+	if new, ok := addr.AddBounded(3, 2, 5); !ok || new != 5 {
+		t.Error(new, ok)
+	}
+	if new, ok := addr.AddBounded(1, 2, 5); ok || new != 5 {
+		t.Error(new, ok)
+	}
+	if new, ok := addr.SubBounded(3, 2, 5); !ok || new != 2 {
+		t.Error(new, ok)
+	}
+	if new, ok := addr.SubBounded(1, 2, 5); ok || new != 2 {
+		t.Error(new, ok)
+	}
+	if new, ok := addr.SubBounded(3, 0, 5); ok || new != 2 {
+		t.Error(new, ok)
+	}
+}
+
+func TestAddBoundedUint8(t *testing.T) {
+	addr := NewUint8(0)
+	var wg sync.WaitGroup
+	for i := 0; i < 8192; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			addr.AddBounded(1, 0, 100)
+		}()
+	}
+	wg.Wait()
+	if addr.Load() != 100 {
+		t.Error(addr.Load())
+	}
+}
+
+func TestFetchUint8(t *testing.T) {
+	addr := NewUint8(6)
+	if addr.FetchAdd(2) != 6 || addr.Load() != 8 {
+		t.Error(addr.Load())
+	}
+	if addr.FetchSub(3) != 8 || addr.Load() != 5 {
+		t.Error(addr.Load())
+	}
+	if addr.FetchAnd(6) != 5 || addr.Load() != 4 {
+		t.Error(addr.Load())
+	}
+	if addr.FetchOr(3) != 4 || addr.Load() != 7 {
+		t.Error(addr.Load())
+	}
+	if addr.CompareAndExchange(7, 9) != 7 || addr.Load() != 9 {
+		t.Error(addr.Load())
+	}
+	if addr.CompareAndExchange(7, 10) != 9 || addr.Load() != 9 {
+		t.Error(addr.Load())
+	}
+}
+
+func TestFetchAddUint8(t *testing.T) {
+	addr := NewUint8(0)
+	var seen [64]Bool
+	var wg sync.WaitGroup
+	for i := 0; i < 64; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if seen[addr.FetchAdd(1)].Swap(true) {
+				t.Error("duplicate")
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestStoreMaxUint8(t *testing.T) {
+	addr := NewUint8(5)
+	if new, changed := addr.StoreMax(3); changed || new != 5 {
+		t.Error(new, changed)
+	}
+	if new, changed := addr.StoreMax(5); changed || new != 5 {
+		t.Error(new, changed)
+	}
+	if new, changed := addr.StoreMax(7); !changed || new != 7 || addr.Load() != 7 {
+		t.Error(new, changed)
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i uint8) {
+			defer wg.Done()
+			addr.StoreMax(i)
+		}(uint8(i))
+	}
+	wg.Wait()
+	if addr.Load() != 99 {
+		t.Error(addr.Load())
+	}
+}
+
+func TestStoreMinUint8(t *testing.T) {
+	addr := NewUint8(5)
+	if new, changed := addr.StoreMin(7); changed || new != 5 {
+		t.Error(new, changed)
+	}
+	if new, changed := addr.StoreMin(5); changed || new != 5 {
+		t.Error(new, changed)
+	}
+	if new, changed := addr.StoreMin(3); !changed || new != 3 || addr.Load() != 3 {
+		t.Error(new, changed)
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i uint8) {
+			defer wg.Done()
+			addr.StoreMin(i)
+		}(uint8(i))
+	}
+	wg.Wait()
+	if addr.Load() != 0 {
+		t.Error(addr.Load())
+	}
+}
+
 func BenchmarkSwapUint8(b *testing.B) {
 	addr := NewUint8(1)
 	for i := 0; i < b.N; i++ {
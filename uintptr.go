@@ -34,6 +34,62 @@ func (addr *Uintptr) Add(delta uintptr) (new uintptr) {
 	return atomic.AddUintptr(&addr.v, delta)
 }
 
+// FetchAdd atomically adds delta to *addr and returns the previous value.
+func (addr *Uintptr) FetchAdd(delta uintptr) (old uintptr) {
+	return FetchAddUintptr(&addr.v, delta)
+}
+
+// FetchSub atomically subtracts delta from *addr and returns the previous value.
+func (addr *Uintptr) FetchSub(delta uintptr) (old uintptr) {
+	return FetchSubUintptr(&addr.v, delta)
+}
+
+// FetchAnd atomically replaces *addr with the bitwise AND of *addr and mask
+// and returns the previous value.
+func (addr *Uintptr) FetchAnd(mask uintptr) (old uintptr) {
+	return FetchAndUintptr(&addr.v, mask)
+}
+
+// FetchOr atomically replaces *addr with the bitwise OR of *addr and mask
+// and returns the previous value.
+func (addr *Uintptr) FetchOr(mask uintptr) (old uintptr) {
+	return FetchOrUintptr(&addr.v, mask)
+}
+
+// CompareAndExchange executes the compare-and-swap operation for an uintptr value
+// and returns the value of *addr it was compared with, which is old if the swap happened.
+func (addr *Uintptr) CompareAndExchange(old, new uintptr) (val uintptr) {
+	return CompareAndExchangeUintptr(&addr.v, old, new)
+}
+
+// StoreMax atomically stores val into *addr if val is greater than *addr,
+// and returns the resulting value and whether it changed.
+func (addr *Uintptr) StoreMax(val uintptr) (new uintptr, changed bool) {
+	for {
+		old := addr.Load()
+		if val <= old {
+			return old, false
+		}
+		if addr.CompareAndSwap(old, val) {
+			return val, true
+		}
+	}
+}
+
+// StoreMin atomically stores val into *addr if val is less than *addr,
+// and returns the resulting value and whether it changed.
+func (addr *Uintptr) StoreMin(val uintptr) (new uintptr, changed bool) {
+	for {
+		old := addr.Load()
+		if val >= old {
+			return old, false
+		}
+		if addr.CompareAndSwap(old, val) {
+			return val, true
+		}
+	}
+}
+
 // Load atomically loads *addr.
 func (addr *Uintptr) Load() (val uintptr) {
 	return atomic.LoadUintptr(&addr.v)
@@ -43,3 +99,76 @@ func (addr *Uintptr) Load() (val uintptr) {
 func (addr *Uintptr) Store(val uintptr) {
 	atomic.StoreUintptr(&addr.v, val)
 }
+
+// maxUintptr is the largest uintptr.
+const maxUintptr = ^uintptr(0)
+
+// AddSaturating atomically adds delta to *addr, clamping the result to the
+// range of uintptr, and returns the new value.
+func (addr *Uintptr) AddSaturating(delta uintptr) (new uintptr) {
+	for {
+		old := addr.Load()
+		val, _ := addUint64(uint64(old), uint64(delta), 0, uint64(maxUintptr))
+		new = uintptr(val)
+		if new == old || addr.CompareAndSwap(old, new) {
+			return
+		}
+	}
+}
+
+// AddChecked atomically adds delta to *addr and returns the new value.
+// If the result overflows uintptr, *addr is left unchanged and ok is false.
+func (addr *Uintptr) AddChecked(delta uintptr) (new uintptr, ok bool) {
+	return addr.AddBounded(delta, 0, maxUintptr)
+}
+
+// AddBounded atomically adds delta to *addr and returns the new value.
+// If the result falls outside [lo, hi], *addr is left unchanged and ok is false.
+func (addr *Uintptr) AddBounded(delta, lo, hi uintptr) (new uintptr, ok bool) {
+	for {
+		old := addr.Load()
+		val, ok := addUint64(uint64(old), uint64(delta), uint64(lo), uint64(hi))
+		if !ok {
+			return old, false
+		}
+		new = uintptr(val)
+		if addr.CompareAndSwap(old, new) {
+			return new, true
+		}
+	}
+}
+
+// SubSaturating atomically subtracts delta from *addr, clamping the result to the
+// range of uintptr, and returns the new value.
+func (addr *Uintptr) SubSaturating(delta uintptr) (new uintptr) {
+	for {
+		old := addr.Load()
+		val, _ := subUint64(uint64(old), uint64(delta), 0, uint64(maxUintptr))
+		new = uintptr(val)
+		if new == old || addr.CompareAndSwap(old, new) {
+			return
+		}
+	}
+}
+
+// SubChecked atomically subtracts delta from *addr and returns the new value.
+// If the result overflows uintptr, *addr is left unchanged and ok is false.
+func (addr *Uintptr) SubChecked(delta uintptr) (new uintptr, ok bool) {
+	return addr.SubBounded(delta, 0, maxUintptr)
+}
+
+// SubBounded atomically subtracts delta from *addr and returns the new value.
+// If the result falls outside [lo, hi], *addr is left unchanged and ok is false.
+func (addr *Uintptr) SubBounded(delta, lo, hi uintptr) (new uintptr, ok bool) {
+	for {
+		old := addr.Load()
+		val, ok := subUint64(uint64(old), uint64(delta), uint64(lo), uint64(hi))
+		if !ok {
+			return old, false
+		}
+		new = uintptr(val)
+		if addr.CompareAndSwap(old, new) {
+			return new, true
+		}
+	}
+}
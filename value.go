@@ -23,6 +23,10 @@ type Value struct {
 	v         atomic.Value
 	EqualFunc EqualFunc
 	AddFunc   AddFunc
+	// state points to the valueState allocated by the first write or Watch.
+	// It lives on the heap, so that its 64-bit generation is aligned even
+	// where a Value is not.
+	state unsafe.Pointer
 }
 
 // ifaceWords is interface{} internal representation.
@@ -76,7 +80,8 @@ func (v *Value) compareAndSwap(old, new interface{}) (swapped bool) {
 		// Complete first store.
 		StorePointer(&vp.data, np.data)
 		StorePointer(&vp.typ, np.typ)
-		return
+		v.changed()
+		return true
 	}
 	if uintptr(typ) == ^uintptr(0) {
 		// First store in progress.
@@ -93,7 +98,11 @@ func (v *Value) compareAndSwap(old, new interface{}) (swapped bool) {
 	if typ != np.typ {
 		panic("github.com/hslam/atomic: new is inconsistently typed value")
 	}
-	return atomic.CompareAndSwapPointer(&vp.data, op.data, np.data)
+	if atomic.CompareAndSwapPointer(&vp.data, op.data, np.data) {
+		v.changed()
+		return true
+	}
+	return false
 }
 
 // Add atomically adds delta to *addr and returns the new value.
@@ -121,4 +130,5 @@ func (v *Value) Load() (x interface{}) {
 // Store of an inconsistent type panics, as does Store(nil).
 func (v *Value) Store(x interface{}) {
 	v.v.Store(x)
+	v.changed()
 }
@@ -0,0 +1,96 @@
+// Copyright (c) 2020 Meng Huang (mhboy@outlook.com)
+// This package is licensed under a MIT license that can be found in the LICENSE file.
+
+package atomic
+
+import (
+	"unsafe"
+)
+
+// versioned is an immutable value and version pair.
+type versioned struct {
+	val     interface{}
+	version uint64
+}
+
+// Versioned provides an atomic load and store of a value paired with a version.
+// Every successful write increments the version, so a read-modify-write can
+// detect an intervening write by comparing versions instead of values.
+// The zero value for a Versioned returns nil and version 0 from LoadVersioned.
+//
+// A Versioned must not be copied after first use.
+type Versioned struct {
+	v unsafe.Pointer
+}
+
+// NewVersioned returns a new Versioned.
+func NewVersioned(val interface{}) *Versioned {
+	addr := &Versioned{}
+	addr.Store(val)
+	return addr
+}
+
+// Swap atomically stores new into *addr and returns the previous *addr value.
+func (addr *Versioned) Swap(new interface{}) (old interface{}) {
+	for {
+		p := addr.load()
+		if addr.compareAndSwap(p, new) {
+			return p.val
+		}
+	}
+}
+
+// CompareAndSwapVersion stores new into *addr only if the version of *addr is still version.
+// It reports whether the swap happened; on success the version is incremented.
+func (addr *Versioned) CompareAndSwapVersion(version uint64, new interface{}) (swapped bool) {
+	p := addr.load()
+	if p.version != version {
+		return false
+	}
+	return addr.compareAndSwap(p, new)
+}
+
+// Load atomically loads *addr.
+func (addr *Versioned) Load() (val interface{}) {
+	return addr.load().val
+}
+
+// LoadVersioned atomically loads *addr and its version.
+func (addr *Versioned) LoadVersioned() (val interface{}, version uint64) {
+	p := addr.load()
+	return p.val, p.version
+}
+
+// Version atomically loads the version of *addr.
+func (addr *Versioned) Version() (version uint64) {
+	return addr.load().version
+}
+
+// Store atomically stores val into *addr and increments the version.
+func (addr *Versioned) Store(val interface{}) {
+	for {
+		if addr.compareAndSwap(addr.load(), val) {
+			return
+		}
+	}
+}
+
+func (addr *Versioned) load() *versioned {
+	p := LoadPointer(&addr.v)
+	if p == nil {
+		return &versioned{}
+	}
+	return (*versioned)(p)
+}
+
+// compareAndSwap replaces the pair p with new at the next version.
+// A fresh pair is allocated for every write, so a pointer comparison
+// cannot succeed against a pair that has since been replaced.
+func (addr *Versioned) compareAndSwap(p *versioned, new interface{}) (swapped bool) {
+	var old unsafe.Pointer
+	if p.version > 0 {
+		old = unsafe.Pointer(p)
+	}
+	next := &versioned{val: new, version: p.version + 1}
+	return CompareAndSwapPointer(&addr.v, old, unsafe.Pointer(next))
+}
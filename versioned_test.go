@@ -0,0 +1,107 @@
+// Copyright (c) 2020 Meng Huang (mhboy@outlook.com)
+// This package is licensed under a MIT license that can be found in the LICENSE file.
+
+package atomic
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestVersioned(t *testing.T) {
+	addr := &Versioned{}
+	if val, version := addr.LoadVersioned(); val != nil || version != 0 {
+		t.Error(val, version)
+	}
+	if !addr.CompareAndSwapVersion(0, "a") {
+		t.Error(addr.Load())
+	}
+	addr = NewVersioned("a")
+	if val, version := addr.LoadVersioned(); val != "a" || version != 1 {
+		t.Error(val, version)
+	}
+	addr.Store("b")
+	if addr.Load() != "b" || addr.Version() != 2 {
+		t.Error(addr.LoadVersioned())
+	}
+	if addr.Swap("c") != "b" || addr.Version() != 3 {
+		t.Error(addr.LoadVersioned())
+	}
+	if addr.CompareAndSwapVersion(2, "d") {
+		t.Error(addr.LoadVersioned())
+	}
+	if !addr.CompareAndSwapVersion(3, "c") {
+		t.Error(addr.LoadVersioned())
+	}
+	if addr.CompareAndSwapVersion(3, "d") {
+		t.Error("the same value at a newer version should not match")
+	}
+	if val, version := addr.LoadVersioned(); val != "c" || version != 4 {
+		t.Error(val, version)
+	}
+}
+
+func TestCompareAndSwapVersionVersioned(t *testing.T) {
+	addr := NewVersioned(0)
+	var wg sync.WaitGroup
+	for i := 0; i < 8192; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				val, version := addr.LoadVersioned()
+				if addr.CompareAndSwapVersion(version, val.(int)+1) {
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if val, version := addr.LoadVersioned(); val != 8192 || version != 8193 {
+		t.Error(val, version)
+	}
+}
+
+func TestSwapVersioned(t *testing.T) {
+	addr := NewVersioned(1)
+	var wg sync.WaitGroup
+	for i := 0; i < 8192; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			addr.Swap(1)
+		}()
+	}
+	wg.Wait()
+	if addr.Version() != 8193 {
+		t.Error(addr.Version())
+	}
+}
+
+func BenchmarkSwapVersioned(b *testing.B) {
+	addr := NewVersioned(1)
+	for i := 0; i < b.N; i++ {
+		addr.Swap(1)
+	}
+}
+
+func BenchmarkCompareAndSwapVersionVersioned(b *testing.B) {
+	addr := NewVersioned(1)
+	for i := 0; i < b.N; i++ {
+		addr.CompareAndSwapVersion(addr.Version(), 1)
+	}
+}
+
+func BenchmarkStoreVersioned(b *testing.B) {
+	addr := NewVersioned(1)
+	for i := 0; i < b.N; i++ {
+		addr.Store(1)
+	}
+}
+
+func BenchmarkLoadVersioned(b *testing.B) {
+	addr := NewVersioned(1)
+	for i := 0; i < b.N; i++ {
+		addr.LoadVersioned()
+	}
+}
@@ -0,0 +1,262 @@
+// Copyright (c) 2020 Meng Huang (mhboy@outlook.com)
+// This package is licensed under a MIT license that can be found in the LICENSE file.
+
+package atomic
+
+import (
+	"math/bits"
+	"unsafe"
+)
+
+// Int32View is an atomic view of an int32 that is not held in an Int32,
+// such as a field of a plain struct.
+type Int32View struct {
+	addr *int32
+}
+
+// Swap atomically stores new into the int32 and returns the previous value.
+func (v *Int32View) Swap(new int32) (old int32) {
+	return SwapInt32(v.addr, new)
+}
+
+// CompareAndSwap executes the compare-and-swap operation for the int32.
+func (v *Int32View) CompareAndSwap(old, new int32) (swapped bool) {
+	return CompareAndSwapInt32(v.addr, old, new)
+}
+
+// Add atomically adds delta to the int32 and returns the new value.
+func (v *Int32View) Add(delta int32) (new int32) {
+	return AddInt32(v.addr, delta)
+}
+
+// Load atomically loads the int32.
+func (v *Int32View) Load() (val int32) {
+	return LoadInt32(v.addr)
+}
+
+// Store atomically stores val into the int32.
+func (v *Int32View) Store(val int32) {
+	StoreInt32(v.addr, val)
+}
+
+// Int64View is an atomic view of an int64 that is not held in an Int64,
+// such as a field of a plain struct or a word of a byte buffer.
+type Int64View struct {
+	addr *int64
+	// swap is set if the int64 is stored in the opposite byte order.
+	swap bool
+}
+
+// Swap atomically stores new into the int64 and returns the previous value.
+func (v *Int64View) Swap(new int64) (old int64) {
+	if v.swap {
+		return int64(bits.ReverseBytes64(uint64(SwapInt64(v.addr, int64(bits.ReverseBytes64(uint64(new)))))))
+	}
+	return SwapInt64(v.addr, new)
+}
+
+// CompareAndSwap executes the compare-and-swap operation for the int64.
+func (v *Int64View) CompareAndSwap(old, new int64) (swapped bool) {
+	if v.swap {
+		return CompareAndSwapInt64(v.addr, int64(bits.ReverseBytes64(uint64(old))), int64(bits.ReverseBytes64(uint64(new))))
+	}
+	return CompareAndSwapInt64(v.addr, old, new)
+}
+
+// Add atomically adds delta to the int64 and returns the new value.
+func (v *Int64View) Add(delta int64) (new int64) {
+	if v.swap {
+		for {
+			old := v.Load()
+			new = old + delta
+			if v.CompareAndSwap(old, new) {
+				return
+			}
+		}
+	}
+	return AddInt64(v.addr, delta)
+}
+
+// Load atomically loads the int64.
+func (v *Int64View) Load() (val int64) {
+	val = LoadInt64(v.addr)
+	if v.swap {
+		val = int64(bits.ReverseBytes64(uint64(val)))
+	}
+	return
+}
+
+// Store atomically stores val into the int64.
+func (v *Int64View) Store(val int64) {
+	if v.swap {
+		val = int64(bits.ReverseBytes64(uint64(val)))
+	}
+	StoreInt64(v.addr, val)
+}
+
+// Uint32View is an atomic view of an uint32 that is not held in an Uint32,
+// such as a field of a plain struct or a word of a byte buffer.
+type Uint32View struct {
+	addr *uint32
+	// swap is set if the uint32 is stored in the opposite byte order.
+	swap bool
+}
+
+// Swap atomically stores new into the uint32 and returns the previous value.
+func (v *Uint32View) Swap(new uint32) (old uint32) {
+	if v.swap {
+		return bits.ReverseBytes32(SwapUint32(v.addr, bits.ReverseBytes32(new)))
+	}
+	return SwapUint32(v.addr, new)
+}
+
+// CompareAndSwap executes the compare-and-swap operation for the uint32.
+func (v *Uint32View) CompareAndSwap(old, new uint32) (swapped bool) {
+	if v.swap {
+		return CompareAndSwapUint32(v.addr, bits.ReverseBytes32(old), bits.ReverseBytes32(new))
+	}
+	return CompareAndSwapUint32(v.addr, old, new)
+}
+
+// Add atomically adds delta to the uint32 and returns the new value.
+func (v *Uint32View) Add(delta uint32) (new uint32) {
+	if v.swap {
+		for {
+			old := v.Load()
+			new = old + delta
+			if v.CompareAndSwap(old, new) {
+				return
+			}
+		}
+	}
+	return AddUint32(v.addr, delta)
+}
+
+// Load atomically loads the uint32.
+func (v *Uint32View) Load() (val uint32) {
+	val = LoadUint32(v.addr)
+	if v.swap {
+		val = bits.ReverseBytes32(val)
+	}
+	return
+}
+
+// Store atomically stores val into the uint32.
+func (v *Uint32View) Store(val uint32) {
+	if v.swap {
+		val = bits.ReverseBytes32(val)
+	}
+	StoreUint32(v.addr, val)
+}
+
+// Uint64View is an atomic view of an uint64 that is not held in an Uint64,
+// such as a field of a plain struct or a word of a byte buffer.
+type Uint64View struct {
+	addr *uint64
+	// swap is set if the uint64 is stored in the opposite byte order.
+	swap bool
+}
+
+// Swap atomically stores new into the uint64 and returns the previous value.
+func (v *Uint64View) Swap(new uint64) (old uint64) {
+	if v.swap {
+		return bits.ReverseBytes64(SwapUint64(v.addr, bits.ReverseBytes64(new)))
+	}
+	return SwapUint64(v.addr, new)
+}
+
+// CompareAndSwap executes the compare-and-swap operation for the uint64.
+func (v *Uint64View) CompareAndSwap(old, new uint64) (swapped bool) {
+	if v.swap {
+		return CompareAndSwapUint64(v.addr, bits.ReverseBytes64(old), bits.ReverseBytes64(new))
+	}
+	return CompareAndSwapUint64(v.addr, old, new)
+}
+
+// Add atomically adds delta to the uint64 and returns the new value.
+func (v *Uint64View) Add(delta uint64) (new uint64) {
+	if v.swap {
+		for {
+			old := v.Load()
+			new = old + delta
+			if v.CompareAndSwap(old, new) {
+				return
+			}
+		}
+	}
+	return AddUint64(v.addr, delta)
+}
+
+// Load atomically loads the uint64.
+func (v *Uint64View) Load() (val uint64) {
+	val = LoadUint64(v.addr)
+	if v.swap {
+		val = bits.ReverseBytes64(val)
+	}
+	return
+}
+
+// Store atomically stores val into the uint64.
+func (v *Uint64View) Store(val uint64) {
+	if v.swap {
+		val = bits.ReverseBytes64(val)
+	}
+	StoreUint64(v.addr, val)
+}
+
+// UintptrView is an atomic view of an uintptr that is not held in an Uintptr,
+// such as a field of a plain struct.
+type UintptrView struct {
+	addr *uintptr
+}
+
+// Swap atomically stores new into the uintptr and returns the previous value.
+func (v *UintptrView) Swap(new uintptr) (old uintptr) {
+	return SwapUintptr(v.addr, new)
+}
+
+// CompareAndSwap executes the compare-and-swap operation for the uintptr.
+func (v *UintptrView) CompareAndSwap(old, new uintptr) (swapped bool) {
+	return CompareAndSwapUintptr(v.addr, old, new)
+}
+
+// Add atomically adds delta to the uintptr and returns the new value.
+func (v *UintptrView) Add(delta uintptr) (new uintptr) {
+	return AddUintptr(v.addr, delta)
+}
+
+// Load atomically loads the uintptr.
+func (v *UintptrView) Load() (val uintptr) {
+	return LoadUintptr(v.addr)
+}
+
+// Store atomically stores val into the uintptr.
+func (v *UintptrView) Store(val uintptr) {
+	StoreUintptr(v.addr, val)
+}
+
+// PointerView is an atomic view of a pointer that is not held in a Pointer,
+// such as a field of a plain struct.
+type PointerView struct {
+	addr *unsafe.Pointer
+}
+
+// Swap atomically stores new into the pointer and returns the previous value.
+func (v *PointerView) Swap(new unsafe.Pointer) (old unsafe.Pointer) {
+	return SwapPointer(v.addr, new)
+}
+
+// CompareAndSwap executes the compare-and-swap operation for the pointer.
+func (v *PointerView) CompareAndSwap(old, new unsafe.Pointer) (swapped bool) {
+	return CompareAndSwapPointer(v.addr, old, new)
+}
+
+// Load atomically loads the pointer.
+func (v *PointerView) Load() (val unsafe.Pointer) {
+	return LoadPointer(v.addr)
+}
+
+// Store atomically stores val into the pointer.
+func (v *PointerView) Store(val unsafe.Pointer) {
+	StorePointer(v.addr, val)
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2020 Meng Huang (mhboy@outlook.com)
+// This package is licensed under a MIT license that can be found in the LICENSE file.
+
+package atomic
+
+import (
+	"sync"
+	"testing"
+	"unsafe"
+)
+
+func TestViews(t *testing.T) {
+	var i32 int32
+	var i64 int64
+	var u32 uint32
+	var u64 uint64
+	var uptr uintptr
+	var ptr unsafe.Pointer
+	v32 := &Int32View{addr: &i32}
+	v64 := &Int64View{addr: &i64}
+	vu32 := &Uint32View{addr: &u32}
+	vu64 := &Uint64View{addr: &u64}
+	vuptr := &UintptrView{addr: &uptr}
+	vptr := &PointerView{addr: &ptr}
+	var wg sync.WaitGroup
+	for i := 0; i < 8192; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v32.Add(1)
+			v64.Add(1)
+			vu32.Add(1)
+			vu64.Add(1)
+			vuptr.Add(1)
+			vptr.CompareAndSwap(nil, unsafe.Pointer(&i32))
+		}()
+	}
+	wg.Wait()
+	if v32.Load() != 8192 || v64.Load() != 8192 || vu32.Load() != 8192 || vu64.Load() != 8192 || vuptr.Load() != 8192 {
+		t.Error(i32, i64, u32, u64, uptr)
+	}
+	if vptr.Load() != unsafe.Pointer(&i32) {
+		t.Error(ptr)
+	}
+	v32.Store(1)
+	v64.Store(1)
+	vu32.Store(1)
+	vu64.Store(1)
+	vuptr.Store(1)
+	vptr.Store(nil)
+	if v32.Swap(2) != 1 || v64.Swap(2) != 1 || vu32.Swap(2) != 1 || vu64.Swap(2) != 1 || vuptr.Swap(2) != 1 || vptr.Swap(nil) != nil {
+		t.Error(i32, i64, u32, u64, uptr, ptr)
+	}
+	if !v32.CompareAndSwap(2, 3) || !v64.CompareAndSwap(2, 3) || !vu32.CompareAndSwap(2, 3) || !vu64.CompareAndSwap(2, 3) || !vuptr.CompareAndSwap(2, 3) {
+		t.Error(i32, i64, u32, u64, uptr)
+	}
+	if v32.CompareAndSwap(2, 3) || v64.CompareAndSwap(2, 3) || vu32.CompareAndSwap(2, 3) || vu64.CompareAndSwap(2, 3) || vuptr.CompareAndSwap(2, 3) {
+		t.Error(i32, i64, u32, u64, uptr)
+	}
+}
+
+func BenchmarkAddInt64View(b *testing.B) {
+	var val int64
+	v := &Int64View{addr: &val}
+	for i := 0; i < b.N; i++ {
+		v.Add(1)
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2020 Meng Huang (mhboy@outlook.com)
+// This package is licensed under a MIT license that can be found in the LICENSE file.
+
+package atomic
+
+import (
+	"context"
+	"sync"
+	"unsafe"
+)
+
+// waitTableSize is the number of buckets in the parking table.
+const waitTableSize = 251
+
+// waitTable parks goroutines keyed by the address of the word they wait on.
+var waitTable [waitTableSize]waitBucket
+
+// waiter is a parked goroutine.
+type waiter struct {
+	addr  unsafe.Pointer
+	ready chan struct{}
+	prev  *waiter
+	next  *waiter
+}
+
+// waitBucket is a list of waiters whose addresses hash to the same bucket.
+type waitBucket struct {
+	mu   sync.Mutex
+	head *waiter
+	tail *waiter
+	// pad avoids false sharing between neighbouring buckets.
+	_ [40]byte
+}
+
+func waitBucketOf(addr unsafe.Pointer) *waitBucket {
+	return &waitTable[(uintptr(addr)>>3)%waitTableSize]
+}
+
+// park blocks until the waiter is woken by unpark or ctx is done.
+// cond is checked under the bucket lock, so a wake that follows a
+// change of the word cannot be missed.
+func park(ctx context.Context, addr unsafe.Pointer, cond func() bool) error {
+	b := waitBucketOf(addr)
+	b.mu.Lock()
+	if !cond() {
+		b.mu.Unlock()
+		return nil
+	}
+	w := &waiter{addr: addr, ready: make(chan struct{})}
+	b.push(w)
+	b.mu.Unlock()
+	select {
+	case <-w.ready:
+		return nil
+	case <-ctx.Done():
+	}
+	b.mu.Lock()
+	select {
+	case <-w.ready:
+		// Woken concurrently with the cancellation; report the wake.
+		b.mu.Unlock()
+		return nil
+	default:
+	}
+	b.remove(w)
+	b.mu.Unlock()
+	return ctx.Err()
+}
+
+// unpark wakes up to n goroutines parked on addr and returns how many were woken.
+// A negative n wakes all of them.
+func unpark(addr unsafe.Pointer, n int) (woken int) {
+	b := waitBucketOf(addr)
+	b.mu.Lock()
+	for w := b.head; w != nil && (n < 0 || woken < n); {
+		next := w.next
+		if w.addr == addr {
+			b.remove(w)
+			close(w.ready)
+			woken++
+		}
+		w = next
+	}
+	b.mu.Unlock()
+	return
+}
+
+func (b *waitBucket) push(w *waiter) {
+	w.prev = b.tail
+	if b.tail != nil {
+		b.tail.next = w
+	} else {
+		b.head = w
+	}
+	b.tail = w
+}
+
+func (b *waitBucket) remove(w *waiter) {
+	if w.prev != nil {
+		w.prev.next = w.next
+	} else {
+		b.head = w.next
+	}
+	if w.next != nil {
+		w.next.prev = w.prev
+	} else {
+		b.tail = w.prev
+	}
+	w.prev, w.next = nil, nil
+}
@@ -0,0 +1,44 @@
+// Copyright (c) 2020 Meng Huang (mhboy@outlook.com)
+// This package is licensed under a MIT license that can be found in the LICENSE file.
+
+package atomic
+
+import (
+	"testing"
+	"time"
+	"unsafe"
+)
+
+func TestWaitBucketShared(t *testing.T) {
+	var words [waitTableSize + 1]struct {
+		Uint32
+		_ uint32
+	}
+	a, b := &words[0].Uint32, &words[waitTableSize].Uint32
+	if waitBucketOf(unsafe.Pointer(&a.v)) != waitBucketOf(unsafe.Pointer(&b.v)) {
+		t.Skip("addresses do not share a bucket")
+	}
+	done := make(chan struct{})
+	go func() {
+		a.Wait(0)
+		close(done)
+	}()
+	for {
+		bucket := waitBucketOf(unsafe.Pointer(&a.v))
+		bucket.mu.Lock()
+		parked := bucket.head != nil
+		bucket.mu.Unlock()
+		if parked {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if b.WakeAll() != 0 {
+		t.Error("woke a waiter on another address")
+	}
+	a.Store(1)
+	if a.WakeAll() != 1 {
+		t.Error("should wake one")
+	}
+	<-done
+}
@@ -0,0 +1,149 @@
+// Copyright (c) 2020 Meng Huang (mhboy@outlook.com)
+// This package is licensed under a MIT license that can be found in the LICENSE file.
+
+package atomic
+
+import (
+	"sync"
+	"sync/atomic"
+	"unsafe"
+)
+
+// Event is a change notification delivered by a Watcher.
+type Event struct {
+	// Value is the value of the Value when the notification was sent.
+	// It is at least as recent as the write numbered Generation.
+	Value interface{}
+	// Generation is the number of successful writes to the Value.
+	Generation uint64
+}
+
+// Watcher receives change notifications from a Value.
+type Watcher struct {
+	// C delivers an Event after each successful Store, Swap, CompareAndSwap
+	// or Add. Rapid updates are coalesced: if the receiver falls behind,
+	// only the most recent Event is kept. C is closed by Stop.
+	C <-chan Event
+
+	c       chan Event
+	v       *Value
+	mu      sync.Mutex
+	last    uint64
+	stopped bool
+}
+
+// valueState holds the generation and the watchers of a Value.
+type valueState struct {
+	gen      uint64
+	watchers unsafe.Pointer
+}
+
+// loadState returns the state of v, allocating it if it does not exist.
+func (v *Value) loadState() *valueState {
+	if ptr := LoadPointer(&v.state); ptr != nil {
+		return (*valueState)(ptr)
+	}
+	CompareAndSwapPointer(&v.state, nil, unsafe.Pointer(&valueState{}))
+	return (*valueState)(LoadPointer(&v.state))
+}
+
+// Watch returns a new Watcher that is notified after each successful write to v.
+// Load remains lock-free; the cost of notification is paid by the writers.
+func (v *Value) Watch() *Watcher {
+	c := make(chan Event, 1)
+	w := &Watcher{C: c, c: c, v: v}
+	state := v.loadState()
+	for {
+		old := LoadPointer(&state.watchers)
+		var list []*Watcher
+		if old != nil {
+			list = append(list, *(*[]*Watcher)(old)...)
+		}
+		list = append(list, w)
+		if CompareAndSwapPointer(&state.watchers, old, unsafe.Pointer(&list)) {
+			return w
+		}
+	}
+}
+
+// Generation returns the number of successful writes to v.
+func (v *Value) Generation() uint64 {
+	ptr := LoadPointer(&v.state)
+	if ptr == nil {
+		return 0
+	}
+	return atomic.LoadUint64(&(*valueState)(ptr).gen)
+}
+
+// Stop unsubscribes the Watcher and closes C.
+// It reports whether the call stopped the Watcher.
+func (w *Watcher) Stop() bool {
+	state := w.v.loadState()
+	for {
+		old := LoadPointer(&state.watchers)
+		if old == nil {
+			break
+		}
+		list := make([]*Watcher, 0, len(*(*[]*Watcher)(old)))
+		for _, watcher := range *(*[]*Watcher)(old) {
+			if watcher != w {
+				list = append(list, watcher)
+			}
+		}
+		var ptr unsafe.Pointer
+		if len(list) > 0 {
+			ptr = unsafe.Pointer(&list)
+		}
+		if CompareAndSwapPointer(&state.watchers, old, ptr) {
+			break
+		}
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.stopped {
+		return false
+	}
+	w.stopped = true
+	close(w.c)
+	return true
+}
+
+// changed records a successful write and notifies the watchers.
+func (v *Value) changed() {
+	state := v.loadState()
+	atomic.AddUint64(&state.gen, 1)
+	ptr := LoadPointer(&state.watchers)
+	if ptr == nil {
+		return
+	}
+	// Load the generation before the value, so that the value is at
+	// least as recent as the generation it is reported with.
+	event := Event{Generation: atomic.LoadUint64(&state.gen)}
+	event.Value = v.Load()
+	for _, w := range *(*[]*Watcher)(ptr) {
+		w.send(event)
+	}
+}
+
+// send delivers the event, replacing a pending one that has not been received yet.
+func (w *Watcher) send(event Event) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.stopped || event.Generation <= w.last {
+		return
+	}
+	w.last = event.Generation
+	select {
+	case w.c <- event:
+		return
+	default:
+	}
+	select {
+	case <-w.c:
+	default:
+	}
+	select {
+	case w.c <- event:
+	default:
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright (c) 2020 Meng Huang (mhboy@outlook.com)
+// This package is licensed under a MIT license that can be found in the LICENSE file.
+
+package atomic
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestWatchValue(t *testing.T) {
+	var equalFunc EqualFunc = func(old, load interface{}) (equal bool) {
+		return old == load
+	}
+	addr := NewValue("a", equalFunc, nil)
+	if addr.Generation() != 1 {
+		t.Error(addr.Generation())
+	}
+	w := addr.Watch()
+	addr.Store("b")
+	if event := <-w.C; event.Value != "b" || event.Generation != 2 {
+		t.Error(event)
+	}
+	addr.Swap("c")
+	if event := <-w.C; event.Value != "c" || event.Generation != 3 {
+		t.Error(event)
+	}
+	if addr.CompareAndSwap("a", "d") {
+		t.Error("should not swap")
+	}
+	if !addr.CompareAndSwap("c", "d") {
+		t.Error("should swap")
+	}
+	if event := <-w.C; event.Value != "d" || event.Generation != 4 {
+		t.Error(event)
+	}
+	if !w.Stop() {
+		t.Error("should stop")
+	}
+	if w.Stop() {
+		t.Error("should be stopped")
+	}
+	addr.Store("e")
+	if _, ok := <-w.C; ok {
+		t.Error("should be closed")
+	}
+}
+
+func TestWatchCoalesce(t *testing.T) {
+	addr := NewString("")
+	w1 := addr.Watch()
+	w2 := addr.Watch()
+	defer w2.Stop()
+	for i := 0; i < 16; i++ {
+		addr.Add("a")
+	}
+	if event := <-w1.C; event.Value != "aaaaaaaaaaaaaaaa" || event.Generation != 17 {
+		t.Error(event)
+	}
+	select {
+	case event := <-w1.C:
+		t.Error(event)
+	default:
+	}
+	w1.Stop()
+	addr.Store("b")
+	if event := <-w2.C; event.Value != "b" || event.Generation != 18 {
+		t.Error(event)
+	}
+}
+
+func TestWatchConcurrent(t *testing.T) {
+	addr := NewBytes(nil)
+	w := addr.Watch()
+	defer w.Stop()
+	var wg sync.WaitGroup
+	for i := 0; i < 64; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			addr.Add([]byte{1})
+		}()
+	}
+	wg.Wait()
+	event := <-w.C
+	if event.Generation != addr.Generation() || len(event.Value.([]byte)) != 64 {
+		t.Error(event.Generation, len(event.Value.([]byte)))
+	}
+}
+
+func BenchmarkStoreValueWatched(b *testing.B) {
+	addr := NewString("")
+	w := addr.Watch()
+	defer w.Stop()
+	for i := 0; i < b.N; i++ {
+		addr.Store("")
+	}
+}
+
+func TestWatchUnaligned(t *testing.T) {
+	// On 32-bit platforms a String after an int32 is only 4-byte aligned.
+	type S struct {
+		flag int32
+		name String
+	}
+	s := &S{}
+	w := s.name.Watch()
+	defer w.Stop()
+	s.name.Store("x")
+	if event := <-w.C; event.Value != "x" || event.Generation != 1 {
+		t.Error(event)
+	}
+	if s.name.Generation() != 1 {
+		t.Error(s.name.Generation())
+	}
+}
+
+func TestWatchFirstWrite(t *testing.T) {
+	var b Bytes
+	w := b.Watch()
+	defer w.Stop()
+	if old := b.Swap([]byte("x")); old != nil {
+		t.Error(old)
+	}
+	if b.Generation() != 1 {
+		t.Error(b.Generation())
+	}
+	if event := <-w.C; string(event.Value.([]byte)) != "x" || event.Generation != 1 {
+		t.Error(event)
+	}
+	select {
+	case event := <-w.C:
+		t.Error(event)
+	default:
+	}
+	var s String
+	if old := s.Swap("x"); old != "" {
+		t.Error(old)
+	}
+	if s.Generation() != 1 {
+		t.Error(s.Generation())
+	}
+}